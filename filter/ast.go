@@ -0,0 +1,186 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// node is a node in the syntax tree of a compiled expression. The record is
+// passed by value, passing a pointer through the interface would cause it to
+// escape to the heap.
+type node interface {
+	eval(r opencdc.Record) (value, error)
+}
+
+type literalNode struct {
+	v value
+}
+
+func (n literalNode) eval(opencdc.Record) (value, error) {
+	return n.v, nil
+}
+
+type pathNode struct {
+	path opencdc.Path
+}
+
+func (n pathNode) eval(r opencdc.Record) (value, error) {
+	v, ok := n.path.Lookup(r)
+	if !ok {
+		// Missing values evaluate to null.
+		return value{kind: kindNull}, nil
+	}
+	return newValue(v), nil
+}
+
+type notNode struct {
+	operand node
+	pos     int
+}
+
+func (n notNode) eval(r opencdc.Record) (value, error) {
+	b, err := evalBool(n.operand, r, n.pos)
+	if err != nil {
+		return value{}, err
+	}
+	return value{kind: kindBool, b: !b}, nil
+}
+
+type andNode struct {
+	left, right node
+	pos         int
+}
+
+func (n andNode) eval(r opencdc.Record) (value, error) {
+	b, err := evalBool(n.left, r, n.pos)
+	if err != nil || !b {
+		return value{kind: kindBool}, err
+	}
+	b, err = evalBool(n.right, r, n.pos)
+	return value{kind: kindBool, b: b}, err
+}
+
+type orNode struct {
+	left, right node
+	pos         int
+}
+
+func (n orNode) eval(r opencdc.Record) (value, error) {
+	b, err := evalBool(n.left, r, n.pos)
+	if err != nil || b {
+		return value{kind: kindBool, b: b}, err
+	}
+	b, err = evalBool(n.right, r, n.pos)
+	return value{kind: kindBool, b: b}, err
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+func (n compareNode) eval(r opencdc.Record) (value, error) {
+	left, err := n.left.eval(r)
+	if err != nil {
+		return value{}, err
+	}
+	right, err := n.right.eval(r)
+	if err != nil {
+		return value{}, err
+	}
+
+	var b bool
+	switch n.op {
+	case "==":
+		b = left.equal(right)
+	case "!=":
+		b = !left.equal(right)
+	default:
+		c, ok := left.compare(right)
+		if ok {
+			switch n.op {
+			case "<":
+				b = c < 0
+			case "<=":
+				b = c <= 0
+			case ">":
+				b = c > 0
+			case ">=":
+				b = c >= 0
+			}
+		}
+	}
+	return value{kind: kindBool, b: b}, nil
+}
+
+type inNode struct {
+	operand node
+	list    []node
+}
+
+func (n inNode) eval(r opencdc.Record) (value, error) {
+	v, err := n.operand.eval(r)
+	if err != nil {
+		return value{}, err
+	}
+	for _, item := range n.list {
+		iv, err := item.eval(r)
+		if err != nil {
+			return value{}, err
+		}
+		if v.equal(iv) {
+			return value{kind: kindBool, b: true}, nil
+		}
+	}
+	return value{kind: kindBool}, nil
+}
+
+type matchNode struct {
+	operand node
+	re      *regexp.Regexp
+	negate  bool
+}
+
+func (n matchNode) eval(r opencdc.Record) (value, error) {
+	v, err := n.operand.eval(r)
+	if err != nil {
+		return value{}, err
+	}
+	if v.kind != kindString {
+		// Only strings can match a regular expression.
+		return value{kind: kindBool, b: n.negate}, nil
+	}
+	return value{kind: kindBool, b: n.re.MatchString(v.str) != n.negate}, nil
+}
+
+// evalBool evaluates the node and returns its boolean value. Null is regarded
+// as false, other non-boolean values produce an error.
+func evalBool(n node, r opencdc.Record, pos int) (bool, error) {
+	v, err := n.eval(r)
+	if err != nil {
+		return false, err
+	}
+	switch v.kind { //nolint:exhaustive // other kinds are not boolean
+	case kindBool:
+		return v.b, nil
+	case kindNull:
+		return false, nil
+	}
+	return false, fmt.Errorf("position %d: expected bool, got %s: %w", pos, v.kind, ErrTypeMismatch)
+}
@@ -0,0 +1,26 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import "errors"
+
+var (
+	// ErrInvalidExpression is returned when a filter expression can't be
+	// compiled.
+	ErrInvalidExpression = errors.New("invalid filter expression")
+	// ErrTypeMismatch is returned when evaluating a filter and a value has a
+	// type that is not supported by an operator.
+	ErrTypeMismatch = errors.New("type mismatch")
+)
@@ -0,0 +1,92 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package filter provides an expression language for evaluating conditions on
+// OpenCDC records, e.g. to route or drop records.
+//
+// An expression consists of the following elements:
+//   - Record values referenced using opencdc.Path, e.g. .Operation,
+//     .Payload.After.status or .Metadata["opencdc.collection"]. The
+//     operation is compared as a string (e.g. "create"). Values that don't
+//     exist evaluate to null.
+//   - Literals: strings in double quotes or backticks, numbers, true, false
+//     and null.
+//   - Comparison operators: ==, !=, <, <=, > and >=. Only numbers and strings
+//     can be ordered, comparing values of different types evaluates to false.
+//     Integers are compared exactly, regardless of their size, floats are
+//     only used if one of the numbers has a fraction.
+//   - Boolean operators: &&, || and !. Null is regarded as false.
+//   - Membership: .Operation in ["create", "snapshot"].
+//   - Regular expression matching: .Key.email =~ "@example\\.com$" (or !~ for
+//     a negated match). The regular expression needs to be a string literal.
+//   - Parentheses for grouping.
+//
+// Example:
+//
+//	.Operation == "delete" && .Payload.Before.status != "archived"
+package filter
+
+import (
+	"fmt"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// Filter is a compiled filter expression. It is safe for concurrent use.
+type Filter struct {
+	expr string
+	root node
+}
+
+// Compile parses the expression and returns a filter that can be used to
+// evaluate records. It returns ErrInvalidExpression with the position of the
+// problem if the expression is malformed.
+func Compile(expr string) (*Filter, error) {
+	root, err := parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile filter %q: %w", expr, err)
+	}
+	return &Filter{expr: expr, root: root}, nil
+}
+
+// MustCompile is like Compile but panics if the expression can't be parsed.
+func MustCompile(expr string) *Filter {
+	f, err := Compile(expr)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// String returns the source expression of the filter.
+func (f *Filter) String() string {
+	return f.expr
+}
+
+// Evaluate evaluates the filter on the record and returns the result. It
+// returns ErrTypeMismatch if the expression does not produce a boolean value
+// or a boolean operator is applied to a non-boolean value.
+func (f *Filter) Evaluate(r opencdc.Record) (bool, error) {
+	v, err := f.root.eval(r)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate filter %q: %w", f.expr, err)
+	}
+	switch v.kind { //nolint:exhaustive // other kinds are not boolean
+	case kindBool:
+		return v.b, nil
+	case kindNull:
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to evaluate filter %q: expected bool, got %s: %w", f.expr, v.kind, ErrTypeMismatch)
+}
@@ -0,0 +1,219 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/goccy/go-json"
+	"github.com/matryer/is"
+)
+
+func testRecord() opencdc.Record {
+	return opencdc.Record{
+		Position:  opencdc.Position("pos-1"),
+		Operation: opencdc.OperationDelete,
+		Metadata: opencdc.Metadata{
+			opencdc.MetadataCollection: "users",
+			"priority":                 "high",
+		},
+		Key: opencdc.StructuredData{"id": 42},
+		Payload: opencdc.Change{
+			Before: opencdc.StructuredData{
+				"status":  "active",
+				"email":   "foo@example.com",
+				"age":     json.Number("30"),
+				"score":   1.5,
+				"deleted": false,
+				"tags":    []any{"a", "b"},
+				"address": map[string]any{"city": "Berlin"},
+			},
+			After: opencdc.RawData("raw"),
+		},
+	}
+}
+
+func TestFilter_Evaluate(t *testing.T) {
+	testCases := []struct {
+		expr string
+		want bool
+	}{
+		{expr: `.Operation == "delete" && .Payload.Before.status != "archived"`, want: true},
+		{expr: `.Operation == "create"`, want: false},
+		{expr: `.Operation in ["create", "delete"]`, want: true},
+		{expr: `.Operation in []`, want: false},
+		{expr: `.Metadata["opencdc.collection"] == "users"`, want: true},
+		{expr: `.Metadata.priority != "low"`, want: true},
+		{expr: `.Metadata.missing == null`, want: true},
+		{expr: `.Key.id == 42`, want: true},
+		{expr: `.Key.id >= 42 && .Key.id < 43`, want: true},
+		{expr: `.Payload.Before.age > 18`, want: true},
+		{expr: `.Payload.Before.score <= 1.5`, want: true},
+		{expr: `.Payload.Before.status > "a"`, want: true},
+		{expr: `.Payload.Before.status < 5`, want: false},
+		{expr: `.Payload.Before.email =~ "@example\\.com$"`, want: true},
+		{expr: ".Payload.Before.email =~ `^bar`", want: false},
+		{expr: `.Payload.Before.email !~ "^bar"`, want: true},
+		{expr: `.Payload.Before.address.city == "Berlin"`, want: true},
+		{expr: `.Payload.Before.tags[1] == "b"`, want: true},
+		{expr: `.Payload.Before.deleted`, want: false},
+		{expr: `!.Payload.Before.deleted`, want: true},
+		{expr: `.Payload.Before.missing`, want: false},
+		{expr: `.Payload.After.foo == null`, want: true},
+		{expr: `.Payload.After == "raw"`, want: true},
+		{expr: `.Position == "pos-1"`, want: true},
+		{expr: `false || (true && !false)`, want: true},
+		{expr: `true || .Payload.Before.status`, want: true},
+		{expr: `!(.Key.id == 1 || .Key.id == 2)`, want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			is := is.New(t)
+			f, err := Compile(tc.expr)
+			is.NoErr(err)
+			got, err := f.Evaluate(testRecord())
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+		})
+	}
+}
+
+func TestFilter_EvaluateNumbers(t *testing.T) {
+	r := opencdc.Record{
+		Key: opencdc.StructuredData{
+			"id":     int64(9007199254740992), // 2^53
+			"uid":    uint64(18446744073709551615),
+			"neg":    int64(-5),
+			"num":    json.Number("9007199254740993"),
+			"float":  1.5,
+			"whole":  2.0,
+			"intish": int(2),
+		},
+	}
+	testCases := []struct {
+		expr string
+		want bool
+	}{
+		// integers larger than 2^53 are compared exactly
+		{expr: `.Key.id == 9007199254740993`, want: false},
+		{expr: `.Key.id == 9007199254740992`, want: true},
+		{expr: `.Key.id < 9007199254740993`, want: true},
+		{expr: `.Key.num == 9007199254740993`, want: true},
+		{expr: `.Key.num > .Key.id`, want: true},
+		{expr: `.Key.uid == 18446744073709551615`, want: true},
+		{expr: `.Key.uid > .Key.id`, want: true},
+		{expr: `.Key.neg < .Key.uid`, want: true},
+		{expr: `.Key.neg == -5`, want: true},
+		// floats are compared with integers without losing precision
+		{expr: `.Key.neg < -4.5`, want: true},
+		{expr: `.Key.neg > -5.5`, want: true},
+		{expr: `.Key.float > 1`, want: true},
+		{expr: `.Key.float < 2`, want: true},
+		{expr: `.Key.whole == .Key.intish`, want: true},
+		{expr: `.Key.intish == 2.0`, want: true},
+		{expr: `.Key.intish == 2e0`, want: true},
+		{expr: `.Key.uid < 1e20`, want: true},
+		{expr: `.Key.neg > -1e20`, want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			is := is.New(t)
+			got, err := MustCompile(tc.expr).Evaluate(r)
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+		})
+	}
+}
+
+func TestFilter_EvaluateError(t *testing.T) {
+	testCases := []string{
+		`.Payload.Before.status`,
+		`.Payload.Before.status && true`,
+		`!.Key.id`,
+		`"foo"`,
+	}
+
+	for _, expr := range testCases {
+		t.Run(expr, func(t *testing.T) {
+			is := is.New(t)
+			f := MustCompile(expr)
+			_, err := f.Evaluate(testRecord())
+			is.True(errors.Is(err, ErrTypeMismatch))
+		})
+	}
+}
+
+func TestCompile_Error(t *testing.T) {
+	testCases := []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: ``, wantErr: `failed to compile filter "": position 1: unexpected end of expression: invalid filter expression`},
+		{expr: `.Key ==`, wantErr: `failed to compile filter ".Key ==": position 8: unexpected end of expression: invalid filter expression`},
+		{expr: `.Key == "foo`, wantErr: `failed to compile filter ".Key == \"foo": position 9: unterminated string: invalid filter expression`},
+		{expr: `.Foo == 1`, wantErr: `failed to compile filter ".Foo == 1": position 1: path ".Foo": unknown record field "Foo": invalid path: invalid filter expression`},
+		{expr: `.Key == 1 1`, wantErr: `failed to compile filter ".Key == 1 1": position 11: unexpected "1": invalid filter expression`},
+		{expr: `(.Key == 1`, wantErr: `failed to compile filter "(.Key == 1": position 11: expected ")", got end of expression: invalid filter expression`},
+		{expr: `.Key in "foo"`, wantErr: `failed to compile filter ".Key in \"foo\"": position 9: expected list after "in", got "\"foo\"": invalid filter expression`},
+		{expr: `.Key =~ .Key`, wantErr: `failed to compile filter ".Key =~ .Key": position 9: expected regular expression string after "=~", got ".Key": invalid filter expression`},
+		{expr: `.Key =~ "("`, wantErr: "failed to compile filter \".Key =~ \\\"(\\\"\": position 9: invalid regular expression: error parsing regexp: missing closing ): `(`: invalid filter expression"},
+		{expr: `.Key = 1`, wantErr: `failed to compile filter ".Key = 1": position 6: unexpected character '=': invalid filter expression`},
+		{expr: `foo`, wantErr: `failed to compile filter "foo": position 1: unexpected "foo": invalid filter expression`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			is := is.New(t)
+			_, err := Compile(tc.expr)
+			is.True(errors.Is(err, ErrInvalidExpression))
+			is.Equal(err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestFilter_EvaluateAllocations(t *testing.T) {
+	f := MustCompile(`.Operation in ["create", "delete"] && .Payload.Before.status != "archived" && .Key.id > 10`)
+
+	missing := testRecord()
+	missing.Payload.Before = opencdc.StructuredData{"email": "foo@example.com"}
+	missing.Key = opencdc.RawData("raw")
+
+	for name, r := range map[string]opencdc.Record{
+		"existing fields": testRecord(),
+		"missing fields":  missing,
+	} {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+			allocs := testing.AllocsPerRun(100, func() {
+				_, _ = f.Evaluate(r)
+			})
+			is.Equal(allocs, 0.0)
+		})
+	}
+}
+
+func BenchmarkFilter_Evaluate(b *testing.B) {
+	f := MustCompile(`.Operation == "delete" && .Payload.Before.status != "archived"`)
+	r := testRecord()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = f.Evaluate(r)
+	}
+}
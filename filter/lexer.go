@@ -0,0 +1,195 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPath
+	tokenString
+	tokenNumber
+	tokenIdent // true, false, null, in
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // byte offset in the expression, starting at 1
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+// operators ordered so that longer operators are matched first.
+var operators = []string{"==", "!=", "<=", ">=", "&&", "||", "=~", "!~", "<", ">", "!"}
+
+// lex splits the expression into tokens.
+func lex(expr string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(expr) {
+		c := expr[i]
+		start := i
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case c == '.':
+			end, err := scanPath(expr, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+			tokens = append(tokens, token{kind: tokenPath, text: expr[start:i], pos: start + 1})
+			continue
+		case c == '"' || c == '`':
+			end, err := scanString(expr, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+			tokens = append(tokens, token{kind: tokenString, text: expr[start:i], pos: start + 1})
+			continue
+		case isDigit(c) || (c == '-' && i+1 < len(expr) && isDigit(expr[i+1])):
+			i++
+			for i < len(expr) && (isDigit(expr[i]) || expr[i] == '.' || expr[i] == 'e' || expr[i] == 'E' ||
+				((expr[i] == '-' || expr[i] == '+') && (expr[i-1] == 'e' || expr[i-1] == 'E'))) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: expr[start:i], pos: start + 1})
+			continue
+		case isIdentStart(c):
+			for i < len(expr) && isIdentPart(expr[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: expr[start:i], pos: start + 1})
+			continue
+		}
+
+		var kind tokenKind
+		switch c {
+		case '(':
+			kind = tokenLParen
+		case ')':
+			kind = tokenRParen
+		case '[':
+			kind = tokenLBracket
+		case ']':
+			kind = tokenRBracket
+		case ',':
+			kind = tokenComma
+		}
+		if kind != tokenEOF {
+			i++
+			tokens = append(tokens, token{kind: kind, text: expr[start:i], pos: start + 1})
+			continue
+		}
+
+		op := ""
+		for _, o := range operators {
+			if strings.HasPrefix(expr[i:], o) {
+				op = o
+				break
+			}
+		}
+		if op == "" {
+			return nil, fmt.Errorf("position %d: unexpected character %q: %w", start+1, c, ErrInvalidExpression)
+		}
+		i += len(op)
+		tokens = append(tokens, token{kind: tokenOperator, text: op, pos: start + 1})
+	}
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(expr) + 1})
+	return tokens, nil
+}
+
+// scanPath returns the end of the path starting at i. A path consists of
+// identifiers separated by dots and bracketed indices or quoted names.
+func scanPath(expr string, i int) (int, error) {
+	start := i
+	for i < len(expr) {
+		switch c := expr[i]; {
+		case c == '.' || isIdentPart(c):
+			i++
+		case c == '[':
+			i++
+			if i < len(expr) && expr[i] == '"' {
+				end, err := scanString(expr, i)
+				if err != nil {
+					return 0, err
+				}
+				i = end
+			} else {
+				for i < len(expr) && expr[i] != ']' {
+					i++
+				}
+			}
+			if i >= len(expr) || expr[i] != ']' {
+				return 0, fmt.Errorf("position %d: unterminated bracket in path: %w", start+1, ErrInvalidExpression)
+			}
+			i++
+		default:
+			return i, nil
+		}
+	}
+	return i, nil
+}
+
+// scanString returns the end of the quoted string starting at i.
+func scanString(expr string, i int) (int, error) {
+	start := i
+	quote := expr[i]
+	i++
+	for i < len(expr) {
+		switch expr[i] {
+		case '\\':
+			if quote == '"' {
+				i++
+			}
+		case quote:
+			return i + 1, nil
+		}
+		i++
+	}
+	return 0, fmt.Errorf("position %d: unterminated string: %w", start+1, ErrInvalidExpression)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
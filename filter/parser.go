@@ -0,0 +1,257 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// parser is a recursive descent parser for filter expressions. The grammar
+// in order of increasing precedence:
+//
+//	or      = and { "||" and }
+//	and     = unary { "&&" unary }
+//	unary   = "!" unary | compare
+//	compare = primary [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) primary
+//	                  | "in" list
+//	                  | ( "=~" | "!~" ) string ]
+//	list    = "[" [ primary { "," primary } ] "]"
+//	primary = path | string | number | "true" | "false" | "null" | "(" or ")"
+type parser struct {
+	tokens []token
+	i      int
+}
+
+func parse(expr string) (node, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	n, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, p.unexpected(tok)
+	}
+	return n, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.i]
+	if tok.kind != tokenEOF {
+		p.i++
+	}
+	return tok
+}
+
+func (p *parser) isOperator(ops ...string) (token, bool) {
+	tok := p.peek()
+	if tok.kind != tokenOperator {
+		return tok, false
+	}
+	for _, op := range ops {
+		if tok.text == op {
+			return tok, true
+		}
+	}
+	return tok, false
+}
+
+func (p *parser) unexpected(tok token) error {
+	return fmt.Errorf("position %d: unexpected %s: %w", tok.pos, tok, ErrInvalidExpression)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.isOperator("||")
+		if !ok {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left: left, right: right, pos: tok.pos}
+	}
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.isOperator("&&")
+		if !ok {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left: left, right: right, pos: tok.pos}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if tok, ok := p.isOperator("!"); ok {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand, pos: tok.pos}, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *parser) parseCompare() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind == tokenIdent && tok.text == "in" {
+		p.next()
+		list, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return inNode{operand: left, list: list}, nil
+	}
+
+	if tok, ok := p.isOperator("=~", "!~"); ok {
+		p.next()
+		patternTok := p.next()
+		if patternTok.kind != tokenString {
+			return nil, fmt.Errorf("position %d: expected regular expression string after %q, got %s: %w", patternTok.pos, tok.text, patternTok, ErrInvalidExpression)
+		}
+		pattern, err := unquote(patternTok)
+		if err != nil {
+			return nil, err
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("position %d: invalid regular expression: %v: %w", patternTok.pos, err, ErrInvalidExpression)
+		}
+		return matchNode{operand: left, re: re, negate: tok.text == "!~"}, nil
+	}
+
+	if tok, ok := p.isOperator("==", "!=", "<", "<=", ">", ">="); ok {
+		p.next()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return compareNode{op: tok.text, left: left, right: right}, nil
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseList() ([]node, error) {
+	tok := p.next()
+	if tok.kind != tokenLBracket {
+		return nil, fmt.Errorf("position %d: expected list after \"in\", got %s: %w", tok.pos, tok, ErrInvalidExpression)
+	}
+	var list []node
+	if p.peek().kind == tokenRBracket {
+		p.next()
+		return list, nil
+	}
+	for {
+		item, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+
+		tok := p.next()
+		switch tok.kind { //nolint:exhaustive // other tokens are unexpected
+		case tokenComma:
+			continue
+		case tokenRBracket:
+			return list, nil
+		}
+		return nil, p.unexpected(tok)
+	}
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.next()
+	switch tok.kind { //nolint:exhaustive // other tokens are unexpected
+	case tokenPath:
+		path, err := opencdc.ParsePath(tok.text)
+		if err != nil {
+			return nil, fmt.Errorf("position %d: %v: %w", tok.pos, err, ErrInvalidExpression)
+		}
+		return pathNode{path: path}, nil
+	case tokenString:
+		s, err := unquote(tok)
+		if err != nil {
+			return nil, err
+		}
+		return literalNode{v: value{kind: kindString, str: s}}, nil
+	case tokenNumber:
+		n, err := parseNumber(tok.text)
+		if err != nil {
+			return nil, fmt.Errorf("position %d: invalid number %s: %w", tok.pos, tok, ErrInvalidExpression)
+		}
+		return literalNode{v: n}, nil
+	case tokenIdent:
+		switch tok.text {
+		case "true":
+			return literalNode{v: value{kind: kindBool, b: true}}, nil
+		case "false":
+			return literalNode{v: value{kind: kindBool, b: false}}, nil
+		case "null":
+			return literalNode{v: value{kind: kindNull}}, nil
+		}
+	case tokenLParen:
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, fmt.Errorf("position %d: expected \")\", got %s: %w", closing.pos, closing, ErrInvalidExpression)
+		}
+		return n, nil
+	}
+	return nil, p.unexpected(tok)
+}
+
+func unquote(tok token) (string, error) {
+	s, err := strconv.Unquote(tok.text)
+	if err != nil {
+		return "", fmt.Errorf("position %d: invalid string %s: %w", tok.pos, tok.text, ErrInvalidExpression)
+	}
+	return s, nil
+}
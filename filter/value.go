@@ -0,0 +1,255 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filter
+
+import (
+	"cmp"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/goccy/go-json"
+)
+
+type valueKind int
+
+const (
+	kindNull valueKind = iota
+	kindBool
+	kindNumber
+	kindString
+	kindOther
+)
+
+func (k valueKind) String() string {
+	switch k {
+	case kindNull:
+		return "null"
+	case kindBool:
+		return "bool"
+	case kindNumber:
+		return "number"
+	case kindString:
+		return "string"
+	default:
+		return "value"
+	}
+}
+
+// numberKind defines which field of a value holds a number. Integers are kept
+// in their exact representation, so that e.g. IDs larger than 2^53 can be
+// compared without losing precision.
+type numberKind int
+
+const (
+	numberFloat numberKind = iota
+	numberInt
+	numberUint
+)
+
+// value is the result of evaluating an expression. Values are kept in a
+// struct instead of an interface to avoid allocations while evaluating.
+type value struct {
+	kind    valueKind
+	b       bool
+	numKind numberKind
+	num     float64
+	i       int64
+	u       uint64
+	str     string
+	other   any
+}
+
+func floatValue(f float64) value { return value{kind: kindNumber, numKind: numberFloat, num: f} }
+func intValue(i int64) value     { return value{kind: kindNumber, numKind: numberInt, i: i} }
+func uintValue(u uint64) value   { return value{kind: kindNumber, numKind: numberUint, u: u} }
+
+// parseNumber parses the textual representation of a number. Integers are
+// parsed exactly, other numbers as float64.
+func parseNumber(s string) (value, error) {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return intValue(i), nil
+	}
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return uintValue(u), nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return value{}, err //nolint:wrapcheck // callers add context
+	}
+	return floatValue(f), nil
+}
+
+// newValue converts a value retrieved from a record into a value.
+func newValue(v any) value {
+	switch v := v.(type) {
+	case nil:
+		return value{kind: kindNull}
+	case bool:
+		return value{kind: kindBool, b: v}
+	case string:
+		return value{kind: kindString, str: v}
+	case float64:
+		return floatValue(v)
+	case int:
+		return intValue(int64(v))
+	case int64:
+		return intValue(v)
+	case json.Number:
+		n, err := parseNumber(string(v))
+		if err != nil {
+			return value{kind: kindString, str: string(v)}
+		}
+		return n
+	case opencdc.Operation:
+		return value{kind: kindString, str: v.String()}
+	case opencdc.Position:
+		return value{kind: kindString, str: string(v)}
+	case opencdc.RawData:
+		return value{kind: kindString, str: string(v)}
+	case []byte:
+		return value{kind: kindString, str: string(v)}
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() { //nolint:exhaustive // other kinds are compared as is
+	case reflect.Bool:
+		return value{kind: kindBool, b: rv.Bool()}
+	case reflect.String:
+		return value{kind: kindString, str: rv.String()}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intValue(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return uintValue(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return floatValue(rv.Float())
+	case reflect.Map, reflect.Slice, reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return value{kind: kindNull}
+		}
+	}
+	return value{kind: kindOther, other: v}
+}
+
+func (v value) equal(o value) bool {
+	if v.kind != o.kind {
+		return false
+	}
+	switch v.kind {
+	case kindNull:
+		return true
+	case kindBool:
+		return v.b == o.b
+	case kindNumber:
+		c, ok := compareNumbers(v, o)
+		return ok && c == 0
+	case kindString:
+		return v.str == o.str
+	default:
+		return reflect.DeepEqual(v.other, o.other)
+	}
+}
+
+// compare returns -1, 0 or 1 if v is less than, equal or greater than o. The
+// second return value is false if the values can't be ordered.
+func (v value) compare(o value) (int, bool) {
+	if v.kind != o.kind {
+		return 0, false
+	}
+	switch v.kind { //nolint:exhaustive // other kinds can't be ordered
+	case kindNumber:
+		return compareNumbers(v, o)
+	case kindString:
+		switch {
+		case v.str < o.str:
+			return -1, true
+		case v.str > o.str:
+			return 1, true
+		default:
+			return 0, true
+		}
+	}
+	return 0, false
+}
+
+// compareNumbers compares two number values. Integers are compared exactly,
+// floats are only used if one of the values is a float. The second return
+// value is false if one of the values is NaN.
+func compareNumbers(a, b value) (int, bool) {
+	switch {
+	case a.numKind == numberFloat && b.numKind == numberFloat:
+		return compareFloats(a.num, b.num)
+	case a.numKind == numberFloat:
+		c, ok := compareFloatToInteger(a.num, b)
+		return c, ok
+	case b.numKind == numberFloat:
+		c, ok := compareFloatToInteger(b.num, a)
+		return -c, ok
+	case a.numKind == numberInt && b.numKind == numberInt:
+		return cmp.Compare(a.i, b.i), true
+	case a.numKind == numberUint && b.numKind == numberUint:
+		return cmp.Compare(a.u, b.u), true
+	case a.numKind == numberInt: // b is uint
+		if a.i < 0 {
+			return -1, true
+		}
+		return cmp.Compare(uint64(a.i), b.u), true
+	default: // a is uint, b is int
+		if b.i < 0 {
+			return 1, true
+		}
+		return cmp.Compare(a.u, uint64(b.i)), true
+	}
+}
+
+func compareFloats(a, b float64) (int, bool) {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return 0, false
+	}
+	return cmp.Compare(a, b), true
+}
+
+// compareFloatToInteger compares a float to an integer value without
+// converting the integer to a float, which could lose precision.
+func compareFloatToInteger(f float64, n value) (int, bool) {
+	if math.IsNaN(f) {
+		return 0, false
+	}
+	whole, frac := math.Modf(f)
+	var c int
+	if n.numKind == numberInt {
+		switch {
+		case whole < math.MinInt64:
+			return -1, true
+		case whole >= math.MaxInt64: // MaxInt64 rounds up to 2^63 as a float
+			return 1, true
+		}
+		c = cmp.Compare(int64(whole), n.i)
+	} else {
+		switch {
+		case whole < 0:
+			return -1, true
+		case whole >= math.MaxUint64: // MaxUint64 rounds up to 2^64 as a float
+			return 1, true
+		}
+		c = cmp.Compare(uint64(whole), n.u)
+	}
+	if c != 0 {
+		return c, true
+	}
+	// the whole parts are equal, the fraction decides
+	return cmp.Compare(frac, 0), true
+}
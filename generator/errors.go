@@ -0,0 +1,26 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import "errors"
+
+var (
+	// ErrUnsupportedType is returned when creating a generator for a field
+	// or schema type that can't be generated.
+	ErrUnsupportedType = errors.New("unsupported type")
+	// ErrInvalidOptions is returned when creating a generator with invalid
+	// options.
+	ErrInvalidOptions = errors.New("invalid generator options")
+)
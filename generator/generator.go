@@ -0,0 +1,283 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package generator produces random records for tests and benchmarks. The
+// payload of the records is generated either based on an Avro schema or a
+// description of field types. Generators are deterministic, two generators
+// created with the same options produce the same records.
+package generator
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/goccy/go-json"
+)
+
+// Format defines how the key or payload of a generated record is encoded.
+type Format int
+
+const (
+	// FormatStructured produces opencdc.StructuredData.
+	FormatStructured Format = iota
+	// FormatRaw produces opencdc.RawData. The data is encoded as JSON, or
+	// using the schema, if the generator was created from a schema.
+	FormatRaw
+)
+
+// DefaultStartTime is the time of the first generated record if
+// Options.StartTime is not set. It is fixed, so generated records don't
+// depend on the time when the generator is created.
+var DefaultStartTime = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Options configure the records produced by a Generator.
+type Options struct {
+	// Seed is the seed of the random number generator.
+	Seed int64
+	// Operations defines the mix of operations as weights, e.g.
+	// {create: 3, update: 1} produces on average three creates for every
+	// update. Updates and deletes only target keys that were created before
+	// and not deleted yet, if there are none a create is produced instead.
+	// Defaults to only producing creates.
+	Operations map[opencdc.Operation]int
+	// KeyFormat is the format of the key. The key contains the field "id"
+	// with the sequence number of the create that produced the key.
+	KeyFormat Format
+	// PayloadFormat is the format of the payload.
+	PayloadFormat Format
+	// Collection is stored in the metadata of the records, if set.
+	Collection string
+	// StartTime is stored as opencdc.createdAt in the metadata of the first
+	// record, the time of each following record is increased by Interval.
+	// Defaults to DefaultStartTime.
+	StartTime time.Time
+	// Interval is the time between two records. Defaults to 1ms.
+	Interval time.Duration
+}
+
+// Generator produces random records. A generator is not safe for concurrent
+// use.
+type Generator struct {
+	opts    Options
+	rand    *rand.Rand
+	payload valueGenerator
+	marshal func(any) ([]byte, error)
+	// schemaMetadata is added to the metadata of records with a raw payload.
+	schemaMetadata func(opencdc.Metadata)
+
+	operations []opencdc.Operation // operations sorted for determinism
+	weights    []int               // cumulative weights of operations
+
+	seq  int64
+	keys []int64 // live keys
+}
+
+// FieldType is the type of a generated field.
+type FieldType int
+
+const (
+	FieldTypeString FieldType = iota + 1 // random alphanumeric string
+	FieldTypeInt                         // random non-negative int64
+	FieldTypeFloat                       // random float64 in [0,1)
+	FieldTypeBool                        // random bool
+	FieldTypeTime                        // random time.Time between 2000 and 2030
+	FieldTypeBytes                       // random []byte
+)
+
+// New returns a generator producing payloads with the described fields.
+func New(fields map[string]FieldType, opts Options) (*Generator, error) {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	gens := make([]fieldGenerator, len(names))
+	for i, name := range names {
+		var gen valueGenerator
+		switch fields[name] {
+		case FieldTypeString:
+			gen = genString
+		case FieldTypeInt:
+			gen = func(r *rand.Rand) any { return r.Int63() }
+		case FieldTypeFloat:
+			gen = func(r *rand.Rand) any { return r.Float64() }
+		case FieldTypeBool:
+			gen = func(r *rand.Rand) any { return r.Intn(2) == 1 }
+		case FieldTypeTime:
+			gen = genTime
+		case FieldTypeBytes:
+			gen = genBytes
+		default:
+			return nil, fmt.Errorf("field %q has type %d: %w", name, fields[name], ErrUnsupportedType)
+		}
+		gens[i] = fieldGenerator{name: name, gen: gen}
+	}
+	return newGenerator(opts, genRecord(gens), json.Marshal, nil)
+}
+
+func newGenerator(
+	opts Options,
+	payload valueGenerator,
+	marshal func(any) ([]byte, error),
+	schemaMetadata func(opencdc.Metadata),
+) (*Generator, error) {
+	if len(opts.Operations) == 0 {
+		opts.Operations = map[opencdc.Operation]int{opencdc.OperationCreate: 1}
+	}
+	if opts.StartTime.IsZero() {
+		opts.StartTime = DefaultStartTime
+	}
+	if opts.Interval == 0 {
+		opts.Interval = time.Millisecond
+	}
+
+	g := &Generator{
+		opts:           opts,
+		rand:           rand.New(rand.NewSource(opts.Seed)), //nolint:gosec // not used for security
+		payload:        payload,
+		marshal:        marshal,
+		schemaMetadata: schemaMetadata,
+	}
+	for op := range opts.Operations {
+		g.operations = append(g.operations, op)
+	}
+	sort.Slice(g.operations, func(i, j int) bool { return g.operations[i] < g.operations[j] })
+	total := 0
+	for _, op := range g.operations {
+		w := opts.Operations[op]
+		if w < 0 {
+			return nil, fmt.Errorf("operation %s has negative weight %d: %w", op, w, ErrInvalidOptions)
+		}
+		total += w
+		g.weights = append(g.weights, total)
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("operation weights sum up to 0: %w", ErrInvalidOptions)
+	}
+	return g, nil
+}
+
+// Next returns the next record.
+func (g *Generator) Next() (opencdc.Record, error) {
+	op := g.operation()
+	if (op == opencdc.OperationUpdate || op == opencdc.OperationDelete) && len(g.keys) == 0 {
+		op = opencdc.OperationCreate
+	}
+
+	var id int64
+	switch op { //nolint:exhaustive // create and snapshot produce new keys
+	case opencdc.OperationUpdate:
+		id = g.keys[g.rand.Intn(len(g.keys))]
+	case opencdc.OperationDelete:
+		i := g.rand.Intn(len(g.keys))
+		id = g.keys[i]
+		g.keys = append(g.keys[:i], g.keys[i+1:]...)
+	default:
+		id = g.seq
+		g.keys = append(g.keys, id)
+	}
+
+	rec := opencdc.Record{
+		Position:  opencdc.Position(fmt.Sprintf("%020d", g.seq)),
+		Operation: op,
+		Metadata:  opencdc.Metadata{},
+	}
+	rec.Metadata.SetOpenCDCVersion()
+	rec.Metadata.SetCreatedAt(g.opts.StartTime.Add(time.Duration(g.seq) * g.opts.Interval))
+	if g.opts.Collection != "" {
+		rec.Metadata.SetCollection(g.opts.Collection)
+	}
+	g.seq++
+
+	var err error
+	rec.Key, err = g.data(map[string]any{"id": id}, g.opts.KeyFormat, json.Marshal)
+	if err != nil {
+		return opencdc.Record{}, fmt.Errorf("failed to encode key: %w", err)
+	}
+
+	if op == opencdc.OperationDelete {
+		return rec, nil
+	}
+	rec.Payload.After, err = g.data(g.payload(g.rand).(map[string]any), g.opts.PayloadFormat, g.marshal)
+	if err != nil {
+		return opencdc.Record{}, fmt.Errorf("failed to encode payload: %w", err)
+	}
+	if g.opts.PayloadFormat == FormatRaw && g.schemaMetadata != nil {
+		g.schemaMetadata(rec.Metadata)
+	}
+	return rec, nil
+}
+
+// operation returns a random operation based on the weights.
+func (g *Generator) operation() opencdc.Operation {
+	n := g.rand.Intn(g.weights[len(g.weights)-1])
+	i := sort.SearchInts(g.weights, n+1)
+	return g.operations[i]
+}
+
+func (g *Generator) data(v map[string]any, format Format, marshal func(any) ([]byte, error)) (opencdc.Data, error) {
+	if format == FormatStructured {
+		return opencdc.StructuredData(v), nil
+	}
+	b, err := marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return opencdc.RawData(b), nil
+}
+
+// valueGenerator produces a random value.
+type valueGenerator func(*rand.Rand) any
+
+type fieldGenerator struct {
+	name string
+	gen  valueGenerator
+}
+
+func genRecord(fields []fieldGenerator) valueGenerator {
+	return func(r *rand.Rand) any {
+		m := make(map[string]any, len(fields))
+		for _, f := range fields {
+			m[f.name] = f.gen(r)
+		}
+		return m
+	}
+}
+
+const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+func genString(r *rand.Rand) any {
+	b := make([]byte, 4+r.Intn(12))
+	for i := range b {
+		b[i] = letters[r.Intn(len(letters))]
+	}
+	return string(b)
+}
+
+func genBytes(r *rand.Rand) any {
+	b := make([]byte, 4+r.Intn(12))
+	_, _ = r.Read(b)
+	return b
+}
+
+// genTime returns a time with millisecond precision in the range of years
+// 2000 to 2030, so it can be represented by all Avro timestamp types.
+func genTime(r *rand.Rand) any {
+	const start, end = 946684800000, 1893456000000 // unix millis
+	return time.UnixMilli(start + r.Int63n(end-start)).UTC()
+}
@@ -0,0 +1,263 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/conduitio/conduit-commons/schema"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hamba/avro/v2"
+	"github.com/matryer/is"
+)
+
+var testFields = map[string]FieldType{
+	"name":      FieldTypeString,
+	"age":       FieldTypeInt,
+	"score":     FieldTypeFloat,
+	"admin":     FieldTypeBool,
+	"createdAt": FieldTypeTime,
+	"avatar":    FieldTypeBytes,
+}
+
+func generate(t *testing.T, g *Generator, n int) []opencdc.Record {
+	t.Helper()
+	is := is.New(t)
+	recs := make([]opencdc.Record, n)
+	for i := range recs {
+		var err error
+		recs[i], err = g.Next()
+		is.NoErr(err)
+	}
+	return recs
+}
+
+func TestGenerator_Deterministic(t *testing.T) {
+	is := is.New(t)
+	opts := Options{
+		Seed: 42,
+		Operations: map[opencdc.Operation]int{
+			opencdc.OperationCreate: 2,
+			opencdc.OperationUpdate: 1,
+			opencdc.OperationDelete: 1,
+		},
+	}
+
+	g1, err := New(testFields, opts)
+	is.NoErr(err)
+	g2, err := New(testFields, opts)
+	is.NoErr(err)
+
+	got1 := generate(t, g1, 100)
+	got2 := generate(t, g2, 100)
+	if diff := cmp.Diff(got1, got2, cmpopts.IgnoreUnexported(opencdc.Record{})); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	opts.Seed = 43
+	g3, err := New(testFields, opts)
+	is.NoErr(err)
+	got3 := generate(t, g3, 100)
+	is.True(!cmp.Equal(got1, got3, cmpopts.IgnoreUnexported(opencdc.Record{})))
+}
+
+func TestGenerator_Records(t *testing.T) {
+	is := is.New(t)
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	g, err := New(testFields, Options{
+		StartTime:  start,
+		Collection: "users",
+		Operations: map[opencdc.Operation]int{
+			opencdc.OperationCreate: 1,
+			opencdc.OperationUpdate: 1,
+			opencdc.OperationDelete: 1,
+		},
+	})
+	is.NoErr(err)
+
+	live := make(map[int64]bool)
+	counts := make(map[opencdc.Operation]int)
+	var prev opencdc.Position
+	for i, r := range generate(t, g, 300) {
+		is.NoErr(r.ValidateStrict())
+		is.True(bytes.Compare(prev, r.Position) < 0) // positions increase
+		prev = r.Position
+
+		createdAt, err := r.Metadata.GetCreatedAt()
+		is.NoErr(err)
+		is.True(createdAt.Equal(start.Add(time.Duration(i) * time.Millisecond)))
+		collection, err := r.Metadata.GetCollection()
+		is.NoErr(err)
+		is.Equal(collection, "users")
+
+		id := r.Key.(opencdc.StructuredData)["id"].(int64)
+		switch r.Operation {
+		case opencdc.OperationCreate:
+			is.True(!live[id])
+			live[id] = true
+		case opencdc.OperationUpdate:
+			is.True(live[id])
+		case opencdc.OperationDelete:
+			is.True(live[id])
+			delete(live, id)
+		}
+		counts[r.Operation]++
+
+		if r.Operation != opencdc.OperationDelete {
+			after := r.Payload.After.(opencdc.StructuredData)
+			is.Equal(len(after), len(testFields))
+			_, ok := after["name"].(string)
+			is.True(ok)
+		}
+	}
+	is.Equal(len(counts), 3)
+}
+
+func TestGenerator_RawJSON(t *testing.T) {
+	is := is.New(t)
+	g, err := New(testFields, Options{KeyFormat: FormatRaw, PayloadFormat: FormatRaw})
+	is.NoErr(err)
+
+	r, err := g.Next()
+	is.NoErr(err)
+	is.Equal(r.Key, opencdc.RawData(`{"id":0}`))
+	_, ok := r.Payload.After.(opencdc.RawData)
+	is.True(ok)
+}
+
+func TestGenerator_Schema(t *testing.T) {
+	is := is.New(t)
+	s := schema.Schema{
+		Subject: "users",
+		Version: 2,
+		Type:    schema.TypeAvro,
+		Bytes: []byte(`{
+  "type": "record",
+  "name": "User",
+  "fields": [
+    {"name": "name", "type": "string"},
+    {"name": "age", "type": "int"},
+    {"name": "score", "type": ["null", "double"]},
+    {"name": "role", "type": {"type": "enum", "name": "Role", "symbols": ["ADMIN", "USER"]}},
+    {"name": "tags", "type": {"type": "array", "items": "string"}},
+    {"name": "attrs", "type": {"type": "map", "values": "long"}},
+    {"name": "createdAt", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+    {"name": "id", "type": {"type": "string", "logicalType": "uuid"}},
+    {"name": "balance", "type": {"type": "bytes", "logicalType": "decimal", "precision": 8, "scale": 2}},
+    {"name": "address", "type": {"type": "record", "name": "Address", "fields": [
+      {"name": "street", "type": "string"}
+    ]}}
+  ]
+}`),
+	}
+
+	g, err := NewFromSchema(s, Options{Seed: 1})
+	is.NoErr(err)
+	for _, r := range generate(t, g, 10) {
+		after := r.Payload.After.(opencdc.StructuredData)
+		is.Equal(len(after), 10)
+		_, err := s.Marshal(map[string]any(after))
+		is.NoErr(err)
+	}
+
+	g, err = NewFromSchema(s, Options{Seed: 1, PayloadFormat: FormatRaw})
+	is.NoErr(err)
+	for _, r := range generate(t, g, 10) {
+		var got map[string]any
+		is.NoErr(s.Unmarshal(r.Payload.After.Bytes(), &got))
+		is.Equal(len(got), 10)
+
+		subject, err := r.Metadata.GetPayloadSchemaSubject()
+		is.NoErr(err)
+		is.Equal(subject, "users")
+		version, err := r.Metadata.GetPayloadSchemaVersion()
+		is.NoErr(err)
+		is.Equal(version, 2)
+	}
+}
+
+func TestGenerator_RecursiveSchema(t *testing.T) {
+	testCases := []struct {
+		name     string
+		schema   string
+		validate bool
+	}{{
+		name:     "array",
+		schema:   `{"type":"record","name":"Tree","fields":[{"name":"v","type":"int"},{"name":"children","type":{"type":"array","items":"Tree"}}]}`,
+		validate: true,
+	}, {
+		name:     "map",
+		schema:   `{"type":"record","name":"Tree","fields":[{"name":"v","type":"int"},{"name":"children","type":{"type":"map","values":"Tree"}}]}`,
+		validate: true,
+	}, {
+		name:   "union",
+		schema: `{"type":"record","name":"List","fields":[{"name":"v","type":"int"},{"name":"next","type":["null","List"]}]}`,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			g, err := NewFromSchema(schema.Schema{Type: schema.TypeAvro, Bytes: []byte(tc.schema)}, Options{Seed: 1})
+			is.NoErr(err)
+			as := avro.MustParse(tc.schema)
+			for _, r := range generate(t, g, 100) {
+				after := map[string]any(r.Payload.After.(opencdc.StructuredData))
+				// each reference nests a record and a container
+				is.True(nestingDepth(after) <= 2*(maxAvroRefDepth+1))
+				if tc.validate {
+					// schema.Schema can't marshal recursive schemas, use the
+					// schema directly to validate the values
+					_, err := avro.Marshal(as, after)
+					is.NoErr(err)
+				}
+			}
+		})
+	}
+}
+
+// nestingDepth returns how deep maps and slices are nested in v.
+func nestingDepth(v any) int {
+	depth := 0
+	switch v := v.(type) {
+	case map[string]any:
+		for _, vv := range v {
+			depth = max(depth, nestingDepth(vv))
+		}
+	case []any:
+		for _, vv := range v {
+			depth = max(depth, nestingDepth(vv))
+		}
+	default:
+		return 0
+	}
+	return depth + 1
+}
+
+func TestGenerator_Errors(t *testing.T) {
+	is := is.New(t)
+
+	_, err := New(map[string]FieldType{"foo": 0}, Options{})
+	is.True(errors.Is(err, ErrUnsupportedType))
+
+	_, err = New(testFields, Options{Operations: map[opencdc.Operation]int{opencdc.OperationCreate: 0}})
+	is.True(errors.Is(err, ErrInvalidOptions))
+
+	_, err = NewFromSchema(schema.Schema{Type: schema.TypeAvro, Bytes: []byte(`"string"`)}, Options{})
+	is.True(errors.Is(err, ErrUnsupportedType))
+}
@@ -0,0 +1,234 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generator
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/conduitio/conduit-commons/schema"
+	"github.com/hamba/avro/v2"
+)
+
+// NewFromSchema returns a generator producing payloads matching the schema.
+// Only Avro schemas of type record are supported. Raw payloads are encoded
+// using the schema and the subject and version of the schema are stored in
+// the metadata of the record.
+func NewFromSchema(s schema.Schema, opts Options) (*Generator, error) {
+	if s.Type != schema.TypeAvro {
+		return nil, fmt.Errorf("schema type %s: %w", s.Type, ErrUnsupportedType)
+	}
+	as, err := avro.ParseBytes(s.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse avro schema: %w", err)
+	}
+	if as.Type() != avro.Record {
+		return nil, fmt.Errorf("schema of type %s is not a record: %w", as.Type(), ErrUnsupportedType)
+	}
+	payload, err := (&avroBuilder{}).generator(as)
+	if err != nil {
+		return nil, err
+	}
+	return newGenerator(opts, payload, s.Marshal, func(m opencdc.Metadata) {
+		m.SetPayloadSchemaSubject(s.Subject)
+		m.SetPayloadSchemaVersion(s.Version)
+	})
+}
+
+// maxAvroRefDepth is the number of nested references to a recursive schema
+// after which unions generate null and arrays and maps are generated empty,
+// so that values of recursive schemas are finite.
+const maxAvroRefDepth = 4
+
+// avroBuilder builds generators of values matching an Avro schema. It tracks
+// how deep the generated value is nested in recursive schemas.
+type avroBuilder struct {
+	refDepth int
+}
+
+// deep reports whether the generated value is nested so deep in recursive
+// schemas that it should not grow any further.
+func (b *avroBuilder) deep() bool {
+	return b.refDepth >= maxAvroRefDepth
+}
+
+// generator returns a generator of values matching the Avro schema.
+func (b *avroBuilder) generator(s avro.Schema) (valueGenerator, error) {
+	if ls, ok := s.(avro.LogicalTypeSchema); ok && ls.Logical() != nil {
+		if gen := avroLogicalGenerator(ls.Logical()); gen != nil {
+			return gen, nil
+		}
+	}
+
+	switch s := s.(type) {
+	case *avro.NullSchema:
+		return func(*rand.Rand) any { return nil }, nil
+	case *avro.PrimitiveSchema:
+		return avroPrimitiveGenerator(s.Type())
+	case *avro.RecordSchema:
+		fields := make([]fieldGenerator, len(s.Fields()))
+		for i, f := range s.Fields() {
+			gen, err := b.generator(f.Type())
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", f.Name(), err)
+			}
+			fields[i] = fieldGenerator{name: f.Name(), gen: gen}
+		}
+		return genRecord(fields), nil
+	case *avro.EnumSchema:
+		symbols := s.Symbols()
+		return func(r *rand.Rand) any { return symbols[r.Intn(len(symbols))] }, nil
+	case *avro.FixedSchema:
+		size := s.Size()
+		return func(r *rand.Rand) any {
+			b := make([]byte, size)
+			_, _ = r.Read(b)
+			return b
+		}, nil
+	case *avro.ArraySchema:
+		items, err := b.generator(s.Items())
+		if err != nil {
+			return nil, err
+		}
+		return func(r *rand.Rand) any {
+			n := r.Intn(4)
+			if b.deep() {
+				n = 0
+			}
+			arr := make([]any, n)
+			for i := range arr {
+				arr[i] = items(r)
+			}
+			return arr
+		}, nil
+	case *avro.MapSchema:
+		values, err := b.generator(s.Values())
+		if err != nil {
+			return nil, err
+		}
+		return func(r *rand.Rand) any {
+			n := r.Intn(4)
+			if b.deep() {
+				n = 0
+			}
+			m := make(map[string]any)
+			for ; n > 0; n-- {
+				m[genString(r).(string)] = values(r)
+			}
+			return m
+		}, nil
+	case *avro.UnionSchema:
+		types := make([]valueGenerator, len(s.Types()))
+		for i, t := range s.Types() {
+			gen, err := b.generator(t)
+			if err != nil {
+				return nil, err
+			}
+			types[i] = gen
+		}
+		nullable := slices.ContainsFunc(s.Types(), func(t avro.Schema) bool { return t.Type() == avro.Null })
+		return func(r *rand.Rand) any {
+			i := r.Intn(len(types))
+			if nullable && b.deep() {
+				return nil
+			}
+			return types[i](r)
+		}, nil
+	case *avro.RefSchema:
+		// Recursive schemas are generated lazily, to prevent an endless loop
+		// when creating the generator. Past maxAvroRefDepth nested references
+		// unions generate null and arrays and maps are empty, which ends the
+		// recursion. A reference that is still reached after that can't be
+		// generated (the schema requires an infinite value) and produces nil.
+		var gen valueGenerator
+		return func(r *rand.Rand) any {
+			if b.refDepth > maxAvroRefDepth {
+				return nil
+			}
+			if gen == nil {
+				var err error
+				if gen, err = b.generator(s.Schema()); err != nil {
+					panic(err) // the referenced schema was already validated
+				}
+			}
+			b.refDepth++
+			defer func() { b.refDepth-- }()
+			return gen(r)
+		}, nil
+	default:
+		return nil, fmt.Errorf("avro type %s: %w", s.Type(), ErrUnsupportedType)
+	}
+}
+
+func avroPrimitiveGenerator(t avro.Type) (valueGenerator, error) {
+	switch t { //nolint:exhaustive // other types are not primitive
+	case avro.Boolean:
+		return func(r *rand.Rand) any { return r.Intn(2) == 1 }, nil
+	case avro.Int:
+		return func(r *rand.Rand) any { return int(r.Int31()) }, nil
+	case avro.Long:
+		return func(r *rand.Rand) any { return r.Int63() }, nil
+	case avro.Float:
+		return func(r *rand.Rand) any { return r.Float32() }, nil
+	case avro.Double:
+		return func(r *rand.Rand) any { return r.Float64() }, nil
+	case avro.String:
+		return genString, nil
+	case avro.Bytes:
+		return genBytes, nil
+	default:
+		return nil, fmt.Errorf("avro type %s: %w", t, ErrUnsupportedType)
+	}
+}
+
+// avroLogicalGenerator returns a generator for a logical type or nil if the
+// logical type is not known, in which case the underlying type is generated.
+func avroLogicalGenerator(ls avro.LogicalSchema) valueGenerator {
+	switch ls.Type() { //nolint:exhaustive // other types fall back to the underlying type
+	case avro.Date:
+		return func(r *rand.Rand) any { return genTime(r).(time.Time).Truncate(24 * time.Hour) }
+	case avro.TimestampMillis, avro.TimestampMicros, avro.LocalTimestampMillis, avro.LocalTimestampMicros:
+		return genTime
+	case avro.TimeMillis, avro.TimeMicros:
+		return func(r *rand.Rand) any {
+			return time.Duration(r.Int63n(int64(24 * time.Hour))).Truncate(time.Millisecond)
+		}
+	case avro.UUID:
+		return func(r *rand.Rand) any {
+			b := make([]byte, 16)
+			_, _ = r.Read(b)
+			b[6] = (b[6] & 0x0f) | 0x40 // version 4
+			b[8] = (b[8] & 0x3f) | 0x80 // variant 10
+			return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+		}
+	case avro.Decimal:
+		ds, ok := ls.(*avro.DecimalLogicalSchema)
+		if !ok {
+			return nil
+		}
+		digits := min(ds.Precision(), 18) // stay in range of int64
+		limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil).Int64()
+		return func(r *rand.Rand) any {
+			unscaled := big.NewInt(r.Int63n(limit))
+			return new(big.Rat).SetFrac(unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(ds.Scale())), nil))
+		}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"time"
+)
+
+// Batch is a sequence of records, e.g. records collected by a destination
+// before writing them.
+type Batch []Record
+
+// Size returns an estimate of the size of all records in the batch in bytes
+// (see Record.Size).
+func (b Batch) Size() int {
+	var size int
+	for _, r := range b {
+		size += r.Size()
+	}
+	return size
+}
+
+// LastPosition returns the position of the last record in the batch, which
+// is the position to acknowledge once the batch is processed. It returns nil
+// if the batch is empty.
+func (b Batch) LastPosition() Position {
+	if len(b) == 0 {
+		return nil
+	}
+	return b[len(b)-1].Position
+}
+
+// Split splits the batch into consecutive sub-batches, so that each
+// sub-batch contains at most maxCount records and its size does not exceed
+// maxBytes. A limit of 0 or less means there is no limit. A record that is
+// bigger than maxBytes on its own is put in a separate sub-batch. The
+// sub-batches share the underlying array with the batch.
+func (b Batch) Split(maxBytes, maxCount int) []Batch {
+	if len(b) == 0 {
+		return nil
+	}
+
+	var batches []Batch
+	start, size := 0, 0
+	for i, r := range b {
+		recSize := r.Size()
+		full := (maxCount > 0 && i-start >= maxCount) ||
+			(maxBytes > 0 && size+recSize > maxBytes)
+		if full && i > start {
+			batches = append(batches, b[start:i:i])
+			start, size = i, 0
+		}
+		size += recSize
+	}
+	return append(batches, b[start:])
+}
+
+// estimateSize returns an estimate of the size of v when serialized as JSON.
+func estimateSize(v any) int {
+	const (
+		delimiters = 2 // quotes, braces or brackets
+		separator  = 1 // comma or colon
+		number     = 8 // average size of a number
+	)
+
+	switch v := v.(type) {
+	case nil:
+		return len("null")
+	case string:
+		return len(v) + delimiters
+	case []byte:
+		return (len(v)+2)/3*4 + delimiters // base64
+	case bool:
+		if v {
+			return len("true")
+		}
+		return len("false")
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return number
+	case time.Time:
+		return len(time.RFC3339Nano) + delimiters
+	case StructuredData:
+		return estimateSize(map[string]any(v))
+	case map[string]any:
+		size := delimiters
+		for k, vv := range v {
+			size += separator + len(k) + delimiters + separator + estimateSize(vv)
+		}
+		if len(v) > 0 {
+			size -= separator // no comma before the first entry
+		}
+		return size
+	case []any:
+		size := delimiters
+		for _, vv := range v {
+			size += separator + estimateSize(vv)
+		}
+		if len(v) > 0 {
+			size -= separator // no comma before the first element
+		}
+		return size
+	default:
+		return number
+	}
+}
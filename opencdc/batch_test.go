@@ -0,0 +1,131 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestStructuredData_Size(t *testing.T) {
+	is := is.New(t)
+
+	d := StructuredData{
+		"name":   "foo",
+		"admin":  true,
+		"nested": map[string]any{"tags": []any{"a", "b"}},
+		"empty":  nil,
+	}
+	// the estimate matches the JSON size for values without numbers
+	is.Equal(d.Size(), len(d.Bytes()))
+
+	d["age"] = 42
+	is.True(d.Size() > len(d.Bytes())-8 && d.Size() < len(d.Bytes())+8)
+
+	// byte slices are base64 encoded in JSON
+	d = StructuredData{"bytes": []byte("foobar")}
+	is.Equal(d.Size(), len(d.Bytes()))
+}
+
+func TestData_Size(t *testing.T) {
+	is := is.New(t)
+
+	// both types of data estimate the size of Bytes
+	raw := RawData(`{"name":"foo"}`)
+	structured := StructuredData{"name": "foo"}
+	is.Equal(raw.Size(), len(raw.Bytes()))
+	is.Equal(structured.Size(), len(structured.Bytes()))
+	is.Equal(raw.Size(), structured.Size())
+}
+
+func TestRecord_Size(t *testing.T) {
+	is := is.New(t)
+
+	r := Record{
+		Position:  Position("pos"),
+		Operation: OperationCreate,
+		Metadata:  Metadata{"foo": "bar"},
+		Key:       RawData("key"),
+		Payload: Change{
+			After: StructuredData{"foo": "bar"},
+		},
+	}
+	want := len("pos") + len("create") + len("foo") + len("bar") + len("key") + len(`{"foo":"bar"}`)
+	is.Equal(r.Size(), want)
+}
+
+func TestBatch_LastPosition(t *testing.T) {
+	is := is.New(t)
+	is.Equal(Batch(nil).LastPosition(), nil)
+	is.Equal(Batch{{Position: Position("1")}, {Position: Position("2")}}.LastPosition(), Position("2"))
+}
+
+func TestBatch_Split(t *testing.T) {
+	newBatch := func(sizes ...int) Batch {
+		b := make(Batch, len(sizes))
+		for i, size := range sizes {
+			b[i] = Record{
+				Operation: OperationCreate,
+				Key:       RawData(bytes.Repeat([]byte{'a'}, size)),
+			}
+		}
+		return b
+	}
+	batchSizes := func(batches []Batch) [][]int {
+		sizes := make([][]int, len(batches))
+		for i, b := range batches {
+			for _, r := range b {
+				sizes[i] = append(sizes[i], r.Key.Size())
+			}
+		}
+		return sizes
+	}
+
+	testCases := []struct {
+		batch    Batch
+		maxBytes int
+		maxCount int
+		want     [][]int
+	}{{
+		batch: newBatch(),
+		want:  [][]int{},
+	}, {
+		batch: newBatch(1, 2, 3),
+		want:  [][]int{{1, 2, 3}},
+	}, {
+		batch:    newBatch(1, 2, 3, 4, 5),
+		maxCount: 2,
+		want:     [][]int{{1, 2}, {3, 4}, {5}},
+	}, {
+		batch:    newBatch(1, 2, 3, 4, 5),
+		maxBytes: 24, // record sizes include 6 bytes for the operation
+		want:     [][]int{{1, 2, 3}, {4, 5}},
+	}, {
+		batch:    newBatch(10, 1, 1, 1, 10),
+		maxBytes: 14,
+		maxCount: 2,
+		want:     [][]int{{10}, {1, 1}, {1}, {10}},
+	}}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%v/%d/%d", batchSizes([]Batch{tc.batch}), tc.maxBytes, tc.maxCount), func(t *testing.T) {
+			is := is.New(t)
+			got := tc.batch.Split(tc.maxBytes, tc.maxCount)
+			is.Equal(batchSizes(got), tc.want)
+		})
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/rabin"
+	"github.com/goccy/go-json"
+)
+
+// Tags used in the canonical encoding to mark values that have no native JSON
+// representation. User map keys starting with canonicalTagPrefix are escaped,
+// so tags never collide with user data.
+const (
+	canonicalTagPrefix   = "$"
+	canonicalTagBytes    = "$bytes"
+	canonicalTagFloat    = "$float"
+	canonicalTagType     = "$type"
+	canonicalTagValue    = "$value"
+	canonicalTagGoString = "$gostring"
+)
+
+// CanonicalBytes returns a deterministic JSON encoding of the record. Two
+// records that are semantically equal produce the same canonical bytes:
+//   - map keys are sorted,
+//   - numbers are normalized (e.g. int(1), int64(1) and float64(1) are all
+//     encoded as 1, float32 values are encoded using their shortest decimal
+//     representation),
+//   - nil and empty metadata are both encoded as an empty object,
+//   - RawData is encoded as a base64 string, StructuredData as an object.
+//
+// Values in StructuredData without a native JSON representation are encoded
+// as tagged objects, so they don't collide with JSON values: byte slices as
+// {"$bytes":"<base64>"}, NaN and infinities as {"$float":"NaN"} and other
+// types (e.g. time.Time) as {"$type":"<go type>","$value":<json>}. Map keys
+// starting with "$" are escaped by doubling the "$".
+//
+// The canonical encoding is meant for comparing and hashing records, not for
+// transferring them, as it does not preserve the types of values.
+func (r Record) CanonicalBytes() []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"position":`)
+	writeCanonicalBytes(&buf, r.Position)
+	buf.WriteString(`,"operation":`)
+	writeCanonicalString(&buf, r.Operation.String())
+	buf.WriteString(`,"metadata":{`)
+	keys := make([]string, 0, len(r.Metadata))
+	for k := range r.Metadata {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeCanonicalString(&buf, k)
+		buf.WriteByte(':')
+		writeCanonicalString(&buf, r.Metadata[k])
+	}
+	buf.WriteString(`},"key":`)
+	writeCanonicalData(&buf, r.Key)
+	buf.WriteString(`,"payload":{"before":`)
+	writeCanonicalData(&buf, r.Payload.Before)
+	buf.WriteString(`,"after":`)
+	writeCanonicalData(&buf, r.Payload.After)
+	buf.WriteString(`}}`)
+	return buf.Bytes()
+}
+
+// Fingerprint returns a 64 bit Rabin fingerprint of the canonical encoding of
+// the record (see CanonicalBytes). Records that are semantically equal have
+// the same fingerprint.
+func (r Record) Fingerprint() uint64 {
+	return rabin.Bytes(r.CanonicalBytes())
+}
+
+// Equal reports whether r and other are semantically equal, i.e. whether they
+// have the same canonical encoding (see CanonicalBytes). Unlike a comparison
+// with reflect.DeepEqual, numbers in StructuredData are compared by value
+// regardless of their type, nested maps are compared regardless of their type
+// (StructuredData or map[string]any) and nil metadata equals empty metadata.
+// The configured serializer is ignored.
+//
+// Note that go-cmp uses this method to compare records, if no option applies
+// to them. Use opencdctest.CmpOptions to compare records field by field.
+func (r Record) Equal(other Record) bool {
+	return bytes.Equal(r.CanonicalBytes(), other.CanonicalBytes())
+}
+
+// CanonicalBytes returns a deterministic JSON encoding of the structured data,
+// where map keys are sorted and numbers are normalized (see
+// Record.CanonicalBytes).
+func (d StructuredData) CanonicalBytes() []byte {
+	var buf bytes.Buffer
+	writeCanonicalValue(&buf, map[string]any(d))
+	return buf.Bytes()
+}
+
+// canonicalData returns the canonical encoding of the data.
+func canonicalData(d Data) []byte {
+	var buf bytes.Buffer
+	writeCanonicalData(&buf, d)
+	return buf.Bytes()
+}
+
+func writeCanonicalData(buf *bytes.Buffer, d Data) {
+	switch d := d.(type) {
+	case nil:
+		buf.WriteString("null")
+	case RawData:
+		writeCanonicalBytes(buf, d)
+	case StructuredData:
+		writeCanonicalValue(buf, map[string]any(d))
+	}
+}
+
+func writeCanonicalValue(buf *bytes.Buffer, v any) {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+		return
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+		return
+	case string:
+		writeCanonicalString(buf, v)
+		return
+	case []byte:
+		writeCanonicalTagged(buf, canonicalTagBytes, func() { writeCanonicalBytes(buf, v) })
+		return
+	}
+
+	if n, ok := asNumber(v); ok {
+		writeCanonicalNumber(buf, n)
+		return
+	}
+	if m, ok := asMap(v); ok {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		buf.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalKey(buf, k)
+			buf.WriteByte(':')
+			writeCanonicalValue(buf, m[k])
+		}
+		buf.WriteByte('}')
+		return
+	}
+	if s, ok := asSlice(v); ok {
+		buf.WriteByte('[')
+		for i, vv := range s {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeCanonicalValue(buf, vv)
+		}
+		buf.WriteByte(']')
+		return
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8 {
+		writeCanonicalTagged(buf, canonicalTagBytes, func() { writeCanonicalBytes(buf, rv.Bytes()) })
+		return
+	}
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			buf.WriteString("null")
+			return
+		}
+		writeCanonicalValue(buf, rv.Elem().Interface())
+		return
+	}
+
+	// Fall back to the JSON representation of the value (e.g. time.Time or
+	// structs) and canonicalize it. The value is tagged with its type, so it
+	// doesn't collide with the JSON value it is encoded as.
+	buf.WriteString(`{"` + canonicalTagType + `":`)
+	writeCanonicalString(buf, rv.Type().String())
+	b, err := json.Marshal(v)
+	if err == nil {
+		var generic any
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		if err := dec.Decode(&generic); err == nil {
+			buf.WriteString(`,"` + canonicalTagValue + `":`)
+			writeCanonicalValue(buf, generic)
+			buf.WriteByte('}')
+			return
+		}
+	}
+	// The value can't be represented as JSON, use its Go representation.
+	buf.WriteString(`,"` + canonicalTagGoString + `":`)
+	writeCanonicalString(buf, fmt.Sprintf("%#v", v))
+	buf.WriteByte('}')
+}
+
+func writeCanonicalNumber(buf *bytes.Buffer, n number) {
+	switch n.kind { //nolint:exhaustive // only normalized kinds are possible
+	case reflect.Int64:
+		buf.WriteString(strconv.FormatInt(n.i, 10))
+	case reflect.Uint64:
+		buf.WriteString(strconv.FormatUint(n.u, 10))
+	default:
+		if math.IsNaN(n.f) || math.IsInf(n.f, 0) {
+			// Not representable in JSON, encode as a tagged string.
+			writeCanonicalTagged(buf, canonicalTagFloat, func() {
+				writeCanonicalString(buf, strconv.FormatFloat(n.f, 'g', -1, 64))
+			})
+			return
+		}
+		buf.WriteString(strconv.FormatFloat(n.f, 'g', -1, 64))
+	}
+}
+
+// writeCanonicalTagged writes an object with a single tag field, the value of
+// the field is written by fn.
+func writeCanonicalTagged(buf *bytes.Buffer, tag string, fn func()) {
+	buf.WriteString(`{"` + tag + `":`)
+	fn()
+	buf.WriteByte('}')
+}
+
+// writeCanonicalKey writes a map key. Keys starting with the tag prefix are
+// escaped by doubling the prefix, so they can't be mistaken for a tag.
+func writeCanonicalKey(buf *bytes.Buffer, k string) {
+	if strings.HasPrefix(k, canonicalTagPrefix) {
+		k = canonicalTagPrefix + k
+	}
+	writeCanonicalString(buf, k)
+}
+
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	b, _ := json.Marshal(s) // marshaling a string never fails
+	buf.Write(b)
+}
+
+func writeCanonicalBytes(buf *bytes.Buffer, b []byte) {
+	buf.WriteByte('"')
+	buf.WriteString(base64.StdEncoding.EncodeToString(b))
+	buf.WriteByte('"')
+}
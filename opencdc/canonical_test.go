@@ -0,0 +1,192 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/google/go-cmp/cmp"
+	"github.com/matryer/is"
+)
+
+// exactRecord is a cmp option that compares records field by field instead of
+// using Record.Equal, so that the types of values are compared exactly. The
+// serializer is ignored.
+var exactRecord = cmp.Transformer("Fields", func(r Record) []any {
+	return []any{r.Position, r.Operation, r.Metadata, r.Key, r.Payload}
+})
+
+func TestRecord_CanonicalBytes(t *testing.T) {
+	is := is.New(t)
+
+	r := Record{
+		Position:  Position("standing"),
+		Operation: OperationUpdate,
+		Metadata:  Metadata{"foo": "bar", "baz": "qux"},
+		Key:       RawData("padlock-key"),
+		Payload: Change{
+			Before: nil,
+			After: StructuredData{
+				"string":  "orange",
+				"int":     1,
+				"float32": float32(1.2),
+				"float64": 2.0,
+				"uint64":  uint64(math.MaxUint64),
+				"nan":     math.NaN(),
+				"bytes":   []byte("foo"),
+				"raw":     RawData("bar"),
+				"nested":  map[string]any{"b": []string{"x"}, "a": StructuredData{"c": nil}},
+				"time":    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				"number":  json.Number("12"),
+				"ptr":     func() *int { v := 5; return &v }(),
+			},
+		},
+	}
+
+	want := `{"position":"c3RhbmRpbmc=","operation":"update","metadata":{"baz":"qux","foo":"bar"},"key":"cGFkbG9jay1rZXk=","payload":{"before":null,"after":{"bytes":{"$bytes":"Zm9v"},"float32":1.2,"float64":2,"int":1,"nan":{"$float":"NaN"},"nested":{"a":{"c":null},"b":["x"]},"number":12,"ptr":5,"raw":{"$bytes":"YmFy"},"string":"orange","time":{"$type":"time.Time","$value":"2024-01-02T03:04:05Z"},"uint64":18446744073709551615}}}`
+	is.Equal(cmp.Diff(want, string(r.CanonicalBytes())), "")
+}
+
+func TestRecord_Equal(t *testing.T) {
+	base := Record{
+		Position:  Position("foo"),
+		Operation: OperationCreate,
+		Metadata:  Metadata{},
+		Key:       StructuredData{"id": 1},
+		Payload: Change{
+			After: StructuredData{
+				"float":  float32(1.5),
+				"nested": map[string]any{"a": int64(1)},
+				"list":   []any{1, "a"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name  string
+		other Record
+		want  bool
+	}{{
+		name: "semantically equal",
+		other: Record{
+			Position:  Position("foo"),
+			Operation: OperationCreate,
+			Metadata:  nil,
+			Key:       StructuredData{"id": 1.0},
+			Payload: Change{
+				After: StructuredData{
+					"float":  1.5,
+					"nested": StructuredData{"a": 1.0},
+					"list":   []any{1.0, "a"},
+				},
+			},
+		},
+		want: true,
+	}, {
+		name: "different metadata",
+		other: func() Record {
+			r := base.Clone()
+			r.Metadata["foo"] = "bar"
+			return r
+		}(),
+		want: false,
+	}, {
+		name: "raw instead of structured key",
+		other: func() Record {
+			r := base.Clone()
+			r.Key = RawData(`{"id":1}`)
+			return r
+		}(),
+		want: false,
+	}, {
+		name: "different nested value",
+		other: func() Record {
+			r := base.Clone()
+			r.Payload.After.(StructuredData)["nested"] = map[string]any{"a": 2}
+			return r
+		}(),
+		want: false,
+	}, {
+		name: "serializer is ignored",
+		other: func() Record {
+			r := base.Clone()
+			r.SetSerializer(JSONSerializer{})
+			return r
+		}(),
+		want: true,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(base.Equal(tc.other), tc.want)
+			is.Equal(tc.other.Equal(base), tc.want)
+			is.Equal(base.Fingerprint() == tc.other.Fingerprint(), tc.want)
+		})
+	}
+}
+
+func TestStructuredData_CanonicalBytes(t *testing.T) {
+	is := is.New(t)
+
+	// map iteration order is random, make sure the output is stable
+	sd := StructuredData{}
+	for i := 0; i < 100; i++ {
+		sd[string(rune('a'+i%26))+string(rune('a'+i/26))] = i
+	}
+	want := sd.CanonicalBytes()
+	for i := 0; i < 10; i++ {
+		is.Equal(sd.CanonicalBytes(), want)
+	}
+	is.Equal(string(StructuredData{"b": 1.0, "a": int8(2)}.CanonicalBytes()), `{"a":2,"b":1}`)
+	is.Equal(string(StructuredData{"$bytes": "Zm9v"}.CanonicalBytes()), `{"$$bytes":"Zm9v"}`)
+}
+
+func TestStructuredData_CanonicalBytes_NoCollisions(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	testCases := []struct {
+		name string
+		a, b any
+	}{
+		{name: "bytes and base64 string", a: []byte("foo"), b: "Zm9v"},
+		{name: "raw data and base64 string", a: RawData("foo"), b: "Zm9v"},
+		{name: "NaN and string", a: math.NaN(), b: "NaN"},
+		{name: "infinity and string", a: math.Inf(1), b: "+Inf"},
+		{name: "time and string", a: now, b: now.Format(time.RFC3339Nano)},
+		{name: "bytes and tagged map", a: []byte("foo"), b: map[string]any{"$bytes": "Zm9v"}},
+		{name: "NaN and tagged map", a: math.NaN(), b: StructuredData{"$float": "NaN"}},
+		{name: "time and tagged map", a: now, b: map[string]any{
+			"$type":  "time.Time",
+			"$value": now.Format(time.RFC3339Nano),
+		}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			a := Record{Payload: Change{After: StructuredData{"a": tc.a}}}
+			b := Record{Payload: Change{After: StructuredData{"a": tc.b}}}
+			is.True(!bytes.Equal(a.CanonicalBytes(), b.CanonicalBytes()))
+			is.True(!a.Equal(b))
+			is.True(a.Fingerprint() != b.Fingerprint())
+			// Diff agrees that the values are different
+			is.Equal(len(Diff(a.Payload.After.(StructuredData), b.Payload.After.(StructuredData))), 1)
+		})
+	}
+}
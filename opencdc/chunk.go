@@ -0,0 +1,462 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// DefaultFileChunkSize is the chunk size used by FileChunker if no chunk size
+// is configured.
+const DefaultFileChunkSize = 1 << 20 // 1 MiB
+
+// FileChunkerOptions customize how FileChunker splits a file into records.
+type FileChunkerOptions struct {
+	// FileName is stored in the metadata of each record (see
+	// MetadataFileName).
+	FileName string
+	// ChunkSize is the maximum size of a chunk in bytes. Defaults to
+	// DefaultFileChunkSize.
+	ChunkSize int
+	// Key is used as the key of each record. Defaults to the hash of the file
+	// as RawData.
+	Key Data
+	// TempDir is the directory used to buffer the contents of readers that
+	// don't implement io.Seeker. Defaults to os.TempDir.
+	TempDir string
+}
+
+// FileChunker splits a file into a sequence of records, each containing one
+// chunk of the file as RawData in Payload.After. Every record contains the
+// file metadata (name, size and SHA-256 hash) and the chunk metadata (index
+// and count), so the file can be reassembled using FileReassembler.
+type FileChunker struct {
+	r       io.ReadSeeker
+	tmp     *os.File
+	options FileChunkerOptions
+
+	size  int64
+	hash  string
+	count int
+	index int
+}
+
+// NewFileChunker returns a chunker that splits the contents of r. The whole
+// content is read once to calculate the size and hash of the file before the
+// first chunk is returned. If r does not implement io.Seeker, the content is
+// buffered in a temporary file, which is removed when the chunker is closed.
+func NewFileChunker(r io.Reader, options FileChunkerOptions) (*FileChunker, error) {
+	if options.ChunkSize <= 0 {
+		options.ChunkSize = DefaultFileChunkSize
+	}
+	c := &FileChunker{options: options}
+
+	h := sha256.New()
+	if rs, ok := r.(io.ReadSeeker); ok {
+		start, err := rs.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reader offset: %w", err)
+		}
+		c.size, err = io.Copy(h, rs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		_, err = rs.Seek(start, io.SeekStart)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewind reader: %w", err)
+		}
+		c.r = rs
+	} else {
+		tmp, err := os.CreateTemp(options.TempDir, "opencdc-chunker-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary file: %w", err)
+		}
+		c.tmp = tmp
+		c.size, err = io.Copy(io.MultiWriter(h, tmp), r)
+		if err == nil {
+			_, err = tmp.Seek(0, io.SeekStart)
+		}
+		if err != nil {
+			_ = c.Close()
+			return nil, fmt.Errorf("failed to buffer file: %w", err)
+		}
+		c.r = tmp
+	}
+
+	c.hash = hex.EncodeToString(h.Sum(nil))
+	c.count = int((c.size + int64(options.ChunkSize) - 1) / int64(options.ChunkSize))
+	if c.count == 0 {
+		c.count = 1 // an empty file is represented by a single empty chunk
+	}
+	return c, nil
+}
+
+// Size returns the size of the file in bytes.
+func (c *FileChunker) Size() int64 { return c.size }
+
+// Hash returns the hex encoded SHA-256 hash of the file.
+func (c *FileChunker) Hash() string { return c.hash }
+
+// ChunkCount returns the number of chunks the file is split into.
+func (c *FileChunker) ChunkCount() int { return c.count }
+
+// Next returns the record containing the next chunk. After the last chunk
+// Next returns io.EOF.
+func (c *FileChunker) Next() (Record, error) {
+	if c.index >= c.count {
+		return Record{}, io.EOF
+	}
+
+	size := int64(c.options.ChunkSize)
+	if remaining := c.size - int64(c.index)*size; remaining < size {
+		size = remaining
+	}
+	data := make([]byte, size)
+	_, err := io.ReadFull(c.r, data)
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to read chunk %d: %w", c.index+1, err)
+	}
+	c.index++
+
+	key := c.options.Key
+	if key == nil {
+		key = RawData(c.hash)
+	} else {
+		key = key.Clone()
+	}
+
+	metadata := Metadata{}
+	if c.options.FileName != "" {
+		metadata.SetFileName(c.options.FileName)
+	}
+	metadata.SetFileSize(c.size)
+	metadata.SetFileHash(c.hash)
+	metadata.SetFileChunked(true)
+	metadata.SetFileChunkIndex(c.index)
+	metadata.SetFileChunkCount(c.count)
+
+	return Record{
+		Position:  Position(c.hash + "/" + strconv.Itoa(c.index)),
+		Operation: OperationCreate,
+		Metadata:  metadata,
+		Key:       key,
+		Payload:   Change{After: RawData(data)},
+	}, nil
+}
+
+// Close removes the temporary file, if one was created.
+func (c *FileChunker) Close() error {
+	if c.tmp == nil {
+		return nil
+	}
+	tmp := c.tmp
+	c.tmp = nil
+	return errors.Join(tmp.Close(), os.Remove(tmp.Name()))
+}
+
+// FileReassemblerOptions customize how FileReassembler buffers chunks.
+type FileReassemblerOptions struct {
+	// MaxMemory is the maximum number of bytes of chunk data buffered in
+	// memory across all incomplete files. Zero means no limit.
+	MaxMemory int64
+	// SpillDir is a directory where chunks are stored once MaxMemory is
+	// reached. If empty, adding a chunk that exceeds the limit returns
+	// ErrMemoryLimitExceeded.
+	SpillDir string
+}
+
+// FileReassembler collects chunk records produced by FileChunker and returns
+// the reassembled file once all chunks were added and the hash of the content
+// matches. Chunks can be added in any order and chunks of multiple files can
+// be interleaved, files are identified by their hash and file name, so files
+// with the same content but different names are reassembled separately.
+// FileReassembler is not safe for concurrent use.
+type FileReassembler struct {
+	options FileReassemblerOptions
+	files   map[fileID]*pendingFile
+	memUsed int64
+}
+
+// fileID identifies a file that is being reassembled.
+type fileID struct {
+	hash string
+	name string
+}
+
+type pendingFile struct {
+	metadata Metadata
+	count    int
+	size     int64
+	chunks   map[int]fileChunk
+}
+
+type fileChunk struct {
+	data []byte
+	path string // set if the chunk was spilled to disk
+	size int64
+}
+
+// NewFileReassembler returns a new reassembler.
+func NewFileReassembler(options FileReassemblerOptions) *FileReassembler {
+	return &FileReassembler{
+		options: options,
+		files:   make(map[fileID]*pendingFile),
+	}
+}
+
+// Add adds a chunk record. If the chunk completes a file, the reassembled
+// file is returned, otherwise the returned file is nil. Adding a chunk that
+// was already added is a no-op. A record that is not chunked is returned as a
+// file containing only its payload. If the hash of the reassembled content
+// does not match, the chunks of the file are discarded and
+// ErrFileHashMismatch is returned.
+func (a *FileReassembler) Add(r Record) (*ReassembledFile, error) {
+	var data []byte
+	switch d := r.Payload.After.(type) {
+	case RawData:
+		data = d
+	case nil:
+	default:
+		return nil, fmt.Errorf("failed to add chunk: %w", ErrInvalidFieldType)
+	}
+
+	hash, err := r.Metadata.GetFileHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to add chunk: %w", err)
+	}
+	id := fileID{hash: hash, name: r.Metadata[MetadataFileName]}
+	chunked, err := r.Metadata.GetFileChunked()
+	if err != nil && !errors.Is(err, ErrMetadataFieldNotFound) {
+		return nil, fmt.Errorf("failed to add chunk: %w", err)
+	}
+	index, count := 1, 1
+	if chunked {
+		index, err = r.Metadata.GetFileChunkIndex()
+		if err != nil {
+			return nil, fmt.Errorf("failed to add chunk: %w", err)
+		}
+		count, err = r.Metadata.GetFileChunkCount()
+		if err != nil {
+			return nil, fmt.Errorf("failed to add chunk: %w", err)
+		}
+		if count < 1 || index < 1 || index > count {
+			return nil, fmt.Errorf("failed to add chunk: chunk %d of %d: %w", index, count, ErrInvalidMetadataValue)
+		}
+	}
+
+	f, ok := a.files[id]
+	if !ok {
+		metadata := make(Metadata, len(r.Metadata))
+		for k, v := range r.Metadata {
+			metadata[k] = v
+		}
+		delete(metadata, MetadataFileChunked)
+		delete(metadata, MetadataFileChunkIndex)
+		delete(metadata, MetadataFileChunkCount)
+		f = &pendingFile{
+			metadata: metadata,
+			count:    count,
+			chunks:   make(map[int]fileChunk, count),
+		}
+		a.files[id] = f
+	}
+	if f.count != count {
+		return nil, fmt.Errorf("failed to add chunk: file %s: expected %d chunks, got %d: %w", hash, f.count, count, ErrInvalidMetadataValue)
+	}
+	if _, ok := f.chunks[index]; ok {
+		return nil, nil // duplicate chunk
+	}
+
+	chunk, err := a.store(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add chunk: %w", err)
+	}
+	f.chunks[index] = chunk
+	f.size += chunk.size
+
+	if len(f.chunks) < f.count {
+		return nil, nil
+	}
+
+	// The file is complete, hand it over to the caller.
+	delete(a.files, id)
+	file := &ReassembledFile{
+		Metadata: f.metadata,
+		Hash:     hash,
+		Size:     f.size,
+		chunks:   make([]fileChunk, f.count),
+	}
+	for i, c := range f.chunks {
+		file.chunks[i-1] = c
+		if c.path == "" {
+			a.memUsed -= c.size
+		}
+	}
+	err = file.verify()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
+// store keeps the chunk data in memory or spills it to disk if the memory
+// limit is reached.
+func (a *FileReassembler) store(data []byte) (fileChunk, error) {
+	size := int64(len(data))
+	if a.options.MaxMemory <= 0 || a.memUsed+size <= a.options.MaxMemory {
+		a.memUsed += size
+		return fileChunk{data: bytes.Clone(data), size: size}, nil
+	}
+	if a.options.SpillDir == "" {
+		return fileChunk{}, ErrMemoryLimitExceeded
+	}
+
+	f, err := os.CreateTemp(a.options.SpillDir, "opencdc-chunk-*")
+	if err != nil {
+		return fileChunk{}, fmt.Errorf("failed to create spill file: %w", err)
+	}
+	_, err = f.Write(data)
+	err = errors.Join(err, f.Close())
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return fileChunk{}, fmt.Errorf("failed to write spill file: %w", err)
+	}
+	return fileChunk{path: f.Name(), size: size}, nil
+}
+
+// Pending returns the number of files that are not complete yet.
+func (a *FileReassembler) Pending() int {
+	return len(a.files)
+}
+
+// Close discards all incomplete files and removes their spilled chunks.
+func (a *FileReassembler) Close() error {
+	var errs []error
+	for id, f := range a.files {
+		for _, c := range f.chunks {
+			if c.path != "" {
+				errs = append(errs, os.Remove(c.path))
+			}
+		}
+		delete(a.files, id)
+	}
+	a.memUsed = 0
+	return errors.Join(errs...)
+}
+
+// ReassembledFile is a file reassembled from chunks. It is a reader returning
+// the content of the file, it needs to be closed to remove chunks spilled to
+// disk.
+type ReassembledFile struct {
+	// Metadata contains the metadata of the first received chunk, without the
+	// chunk specific fields.
+	Metadata Metadata
+	// Hash is the hex encoded SHA-256 hash of the content.
+	Hash string
+	// Size is the size of the content in bytes.
+	Size int64
+
+	chunks []fileChunk
+	index  int
+	cur    io.Reader
+	file   *os.File
+}
+
+// Read reads the content of the file.
+func (f *ReassembledFile) Read(p []byte) (int, error) {
+	for {
+		if f.cur == nil {
+			if f.index >= len(f.chunks) {
+				return 0, io.EOF
+			}
+			c := f.chunks[f.index]
+			f.index++
+			if c.path == "" {
+				f.cur = bytes.NewReader(c.data)
+			} else {
+				file, err := os.Open(c.path)
+				if err != nil {
+					return 0, fmt.Errorf("failed to open spill file: %w", err)
+				}
+				f.file = file
+				f.cur = file
+			}
+		}
+
+		n, err := f.cur.Read(p)
+		if errors.Is(err, io.EOF) {
+			f.cur = nil
+			if f.file != nil {
+				_ = f.file.Close()
+				f.file = nil
+			}
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+// Bytes reads the whole content of the file.
+func (f *ReassembledFile) Bytes() ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, f.Size))
+	_, err := io.Copy(buf, f)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Close removes chunks spilled to disk.
+func (f *ReassembledFile) Close() error {
+	var errs []error
+	if f.file != nil {
+		errs = append(errs, f.file.Close())
+		f.file = nil
+	}
+	for _, c := range f.chunks {
+		if c.path != "" {
+			errs = append(errs, os.Remove(c.path))
+		}
+	}
+	f.chunks = nil
+	f.cur = nil
+	return errors.Join(errs...)
+}
+
+// verify checks the hash of the content and rewinds the reader.
+func (f *ReassembledFile) verify() error {
+	h := sha256.New()
+	_, err := io.Copy(h, f)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); got != f.Hash {
+		return fmt.Errorf("expected hash %s, got %s: %w", f.Hash, got, ErrFileHashMismatch)
+	}
+	f.index = 0
+	f.cur = nil
+	return nil
+}
@@ -0,0 +1,242 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func chunkFile(t *testing.T, r io.Reader, options FileChunkerOptions) []Record {
+	is := is.New(t)
+	c, err := NewFileChunker(r, options)
+	is.NoErr(err)
+	defer func() { is.NoErr(c.Close()) }()
+
+	var records []Record
+	for {
+		rec, err := c.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		is.NoErr(err)
+		is.NoErr(rec.Validate())
+		records = append(records, rec)
+	}
+	is.Equal(len(records), c.ChunkCount())
+	return records
+}
+
+func TestFileChunker(t *testing.T) {
+	is := is.New(t)
+
+	content := []byte("0123456789abcdefghij-")
+	sum := sha256.Sum256(content)
+	wantHash := hex.EncodeToString(sum[:])
+
+	for _, r := range []io.Reader{
+		bytes.NewReader(content),                 // seekable
+		io.MultiReader(bytes.NewReader(content)), // not seekable
+	} {
+		records := chunkFile(t, r, FileChunkerOptions{FileName: "file.txt", ChunkSize: 10, TempDir: t.TempDir()})
+		is.Equal(len(records), 3)
+
+		var got []byte
+		for i, rec := range records {
+			name, err := rec.Metadata.GetFileName()
+			is.NoErr(err)
+			is.Equal(name, "file.txt")
+			size, err := rec.Metadata.GetFileSize()
+			is.NoErr(err)
+			is.Equal(size, int64(len(content)))
+			hash, err := rec.Metadata.GetFileHash()
+			is.NoErr(err)
+			is.Equal(hash, wantHash)
+			chunked, err := rec.Metadata.GetFileChunked()
+			is.NoErr(err)
+			is.True(chunked)
+			index, err := rec.Metadata.GetFileChunkIndex()
+			is.NoErr(err)
+			is.Equal(index, i+1)
+			count, err := rec.Metadata.GetFileChunkCount()
+			is.NoErr(err)
+			is.Equal(count, 3)
+			is.Equal(rec.Key, RawData(wantHash))
+			got = append(got, rec.Payload.After.Bytes()...)
+		}
+		is.Equal(got, content)
+		is.Equal(records[2].Payload.After, RawData("-"))
+	}
+}
+
+func TestFileChunker_Empty(t *testing.T) {
+	is := is.New(t)
+	records := chunkFile(t, bytes.NewReader(nil), FileChunkerOptions{})
+	is.Equal(len(records), 1)
+	is.Equal(records[0].Payload.After, RawData{})
+
+	file, err := NewFileReassembler(FileReassemblerOptions{}).Add(records[0])
+	is.NoErr(err)
+	got, err := file.Bytes()
+	is.NoErr(err)
+	is.Equal(len(got), 0)
+}
+
+func TestFileReassembler(t *testing.T) {
+	content := make([]byte, 1000)
+	rand.New(rand.NewSource(1)).Read(content)
+
+	testCases := []struct {
+		name    string
+		options FileReassemblerOptions
+	}{{
+		name:    "in memory",
+		options: FileReassemblerOptions{},
+	}, {
+		name:    "spill to disk",
+		options: FileReassemblerOptions{MaxMemory: 250, SpillDir: "spill"},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			if tc.options.SpillDir != "" {
+				tc.options.SpillDir = t.TempDir()
+			}
+
+			records := chunkFile(t, bytes.NewReader(content), FileChunkerOptions{FileName: "a.bin", ChunkSize: 100})
+			other := chunkFile(t, bytes.NewReader([]byte("other file")), FileChunkerOptions{ChunkSize: 4})
+
+			// shuffle chunks and interleave them with chunks of another file
+			rand.New(rand.NewSource(2)).Shuffle(len(records), func(i, j int) {
+				records[i], records[j] = records[j], records[i]
+			})
+			records = append(records[:5], append(other, records[5:]...)...)
+
+			a := NewFileReassembler(tc.options)
+			var files []*ReassembledFile
+			for i, rec := range records {
+				f, err := a.Add(rec)
+				is.NoErr(err)
+				if f != nil {
+					files = append(files, f)
+				}
+				if i == 0 {
+					// duplicates are ignored
+					f, err = a.Add(rec)
+					is.NoErr(err)
+					is.True(f == nil)
+				}
+			}
+			is.Equal(a.Pending(), 0)
+			is.Equal(len(files), 2)
+
+			got, err := files[0].Bytes()
+			is.NoErr(err)
+			is.Equal(got, []byte("other file"))
+
+			got, err = files[1].Bytes()
+			is.NoErr(err)
+			is.Equal(got, content)
+			is.Equal(files[1].Size, int64(len(content)))
+			is.Equal(files[1].Metadata, Metadata{
+				MetadataFileName: "a.bin",
+				MetadataFileSize: "1000",
+				MetadataFileHash: files[1].Hash,
+			})
+
+			for _, f := range files {
+				is.NoErr(f.Close())
+			}
+			is.NoErr(a.Close())
+			if tc.options.SpillDir != "" {
+				entries, err := os.ReadDir(tc.options.SpillDir)
+				is.NoErr(err)
+				is.Equal(len(entries), 0)
+			}
+		})
+	}
+}
+
+func TestFileReassembler_SameContent(t *testing.T) {
+	is := is.New(t)
+
+	content := []byte("same content in both files")
+	a := chunkFile(t, bytes.NewReader(content), FileChunkerOptions{FileName: "a.txt", ChunkSize: 10})
+	b := chunkFile(t, bytes.NewReader(content), FileChunkerOptions{FileName: "b.txt", ChunkSize: 10})
+
+	// interleave the chunks of both files
+	var records []Record
+	for i := range a {
+		records = append(records, a[i], b[i])
+	}
+
+	r := NewFileReassembler(FileReassemblerOptions{})
+	var files []*ReassembledFile
+	for _, rec := range records {
+		f, err := r.Add(rec)
+		is.NoErr(err)
+		if f != nil {
+			files = append(files, f)
+		}
+	}
+	is.Equal(r.Pending(), 0)
+	is.Equal(len(files), 2)
+
+	for i, wantName := range []string{"a.txt", "b.txt"} {
+		name, err := files[i].Metadata.GetFileName()
+		is.NoErr(err)
+		is.Equal(name, wantName)
+		got, err := files[i].Bytes()
+		is.NoErr(err)
+		is.Equal(got, content)
+		is.NoErr(files[i].Close())
+	}
+}
+
+func TestFileReassembler_Errors(t *testing.T) {
+	is := is.New(t)
+
+	records := chunkFile(t, bytes.NewReader([]byte("0123456789")), FileChunkerOptions{ChunkSize: 5})
+
+	// memory limit without spilling
+	a := NewFileReassembler(FileReassemblerOptions{MaxMemory: 6})
+	_, err := a.Add(records[0])
+	is.NoErr(err)
+	_, err = a.Add(records[1])
+	is.True(errors.Is(err, ErrMemoryLimitExceeded))
+
+	// corrupted chunk
+	a = NewFileReassembler(FileReassemblerOptions{})
+	corrupted := records[1].Clone()
+	corrupted.Payload.After = RawData("xxxxx")
+	_, err = a.Add(records[0])
+	is.NoErr(err)
+	_, err = a.Add(corrupted)
+	is.True(errors.Is(err, ErrFileHashMismatch))
+	is.Equal(a.Pending(), 0)
+
+	// missing hash
+	_, err = a.Add(Record{Payload: Change{After: RawData("foo")}})
+	is.True(errors.Is(err, ErrMetadataFieldNotFound))
+}
@@ -0,0 +1,108 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"sort"
+)
+
+// Compact compacts the records by key, so that only the final state of each
+// key is kept, similar to log compaction in Kafka. Keys are compared using
+// their canonical encoding (see Record.CanonicalBytes), so keys that only
+// differ in number types (e.g. int(1) and float64(1)) are the same key, while
+// values of different kinds (e.g. a byte slice and its base64 string) are not.
+// The rules are applied to the records of each key in order:
+//   - a create followed by updates is merged into a single create containing
+//     the payload of the last update,
+//   - a create followed by a delete is dropped entirely, unless the create
+//     followed another record of the key, in which case a delete is kept
+//     (the earlier delete, if the create followed a delete),
+//   - in all other cases the latest record is kept.
+//
+// Records without a key are always kept. The returned records are ordered by
+// the position of the last record of their key in the input, which keeps the
+// ordering between keys stable. Kept records retain their position and
+// metadata. Note that the last input record might be dropped, so callers
+// should acknowledge the last position of the input, not of the output. The
+// input records are not modified.
+func Compact(records []Record) []Record {
+	type entry struct {
+		index  int // index of the last record of the key
+		record Record
+		exists bool // false if the records of the key canceled out
+		// replaced is the record replaced by a create, it decides the outcome
+		// if the create is canceled out by a delete
+		replaced *Record
+	}
+
+	entries := make([]*entry, 0, len(records))
+	byKey := make(map[string]*entry)
+	for i, r := range records {
+		if r.Key == nil {
+			entries = append(entries, &entry{index: i, record: r, exists: true})
+			continue
+		}
+
+		key := string(canonicalData(r.Key))
+		e, ok := byKey[key]
+		if !ok {
+			e = &entry{}
+			byKey[key] = e
+			entries = append(entries, e)
+		}
+		e.index = i
+
+		switch {
+		case e.exists && e.record.Operation == OperationCreate && r.Operation == OperationUpdate:
+			r.Operation = OperationCreate
+			r.Payload.Before = nil
+			e.record = r
+		case e.exists && e.record.Operation == OperationCreate && r.Operation == OperationDelete:
+			switch {
+			case e.replaced == nil:
+				// the key was created in this batch, nothing to delete
+				e.record = Record{}
+				e.exists = false
+			case e.replaced.Operation == OperationDelete:
+				e.record = *e.replaced
+			default:
+				// the key existed before the create (e.g. a duplicate create
+				// caused by at-least-once delivery), keep the delete
+				e.record = r
+			}
+			e.replaced = nil
+		case r.Operation == OperationCreate:
+			if e.exists {
+				replaced := e.record
+				e.replaced = &replaced
+			}
+			e.record = r
+			e.exists = true
+		default:
+			e.record = r
+			e.exists = true
+			e.replaced = nil
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].index < entries[j].index })
+	out := make([]Record, 0, len(entries))
+	for _, e := range entries {
+		if e.exists {
+			out = append(out, e.record)
+		}
+	}
+	return out
+}
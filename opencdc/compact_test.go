@@ -0,0 +1,181 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestCompact(t *testing.T) {
+	rec := func(pos string, op Operation, key Data, after Data) Record {
+		r := Record{
+			Position:  Position(pos),
+			Operation: op,
+			Key:       key,
+		}
+		if op == OperationDelete {
+			r.Payload.Before = after
+		} else {
+			r.Payload.Before = StructuredData{"old": true}
+			r.Payload.After = after
+		}
+		if op == OperationCreate {
+			r.Payload.Before = nil
+		}
+		return r
+	}
+
+	testCases := []struct {
+		name string
+		in   []Record
+		want []Record
+	}{{
+		name: "empty",
+		in:   nil,
+		want: []Record{},
+	}, {
+		name: "distinct keys",
+		in: []Record{
+			rec("1", OperationCreate, RawData("a"), StructuredData{"v": 1}),
+			rec("2", OperationUpdate, RawData("b"), StructuredData{"v": 2}),
+		},
+		want: []Record{
+			rec("1", OperationCreate, RawData("a"), StructuredData{"v": 1}),
+			rec("2", OperationUpdate, RawData("b"), StructuredData{"v": 2}),
+		},
+	}, {
+		name: "create and updates are merged",
+		in: []Record{
+			rec("1", OperationCreate, StructuredData{"id": 1}, StructuredData{"v": 1}),
+			rec("2", OperationUpdate, RawData("b"), StructuredData{"v": 1}),
+			rec("3", OperationUpdate, StructuredData{"id": 1.0}, StructuredData{"v": 2}),
+			rec("4", OperationUpdate, StructuredData{"id": int64(1)}, StructuredData{"v": 3}),
+		},
+		want: []Record{
+			rec("2", OperationUpdate, RawData("b"), StructuredData{"v": 1}),
+			rec("4", OperationCreate, StructuredData{"id": int64(1)}, StructuredData{"v": 3}),
+		},
+	}, {
+		name: "updates keep the latest",
+		in: []Record{
+			rec("1", OperationUpdate, RawData("a"), StructuredData{"v": 1}),
+			rec("2", OperationUpdate, RawData("a"), StructuredData{"v": 2}),
+			rec("3", OperationSnapshot, RawData("b"), StructuredData{"v": 1}),
+		},
+		want: []Record{
+			rec("2", OperationUpdate, RawData("a"), StructuredData{"v": 2}),
+			rec("3", OperationSnapshot, RawData("b"), StructuredData{"v": 1}),
+		},
+	}, {
+		name: "create and delete are dropped",
+		in: []Record{
+			rec("1", OperationCreate, RawData("a"), StructuredData{"v": 1}),
+			rec("2", OperationUpdate, RawData("a"), StructuredData{"v": 2}),
+			rec("3", OperationCreate, RawData("b"), StructuredData{"v": 1}),
+			rec("4", OperationDelete, RawData("a"), StructuredData{"v": 2}),
+		},
+		want: []Record{
+			rec("3", OperationCreate, RawData("b"), StructuredData{"v": 1}),
+		},
+	}, {
+		name: "update and delete keep the delete",
+		in: []Record{
+			rec("1", OperationUpdate, RawData("a"), StructuredData{"v": 1}),
+			rec("2", OperationDelete, RawData("a"), StructuredData{"v": 1}),
+			rec("3", OperationCreate, RawData("a"), StructuredData{"v": 2}),
+			rec("4", OperationDelete, RawData("a"), StructuredData{"v": 2}),
+		},
+		want: []Record{
+			rec("2", OperationDelete, RawData("a"), StructuredData{"v": 1}),
+		},
+	}, {
+		name: "duplicate create and delete keep the delete",
+		in: []Record{
+			rec("1", OperationCreate, RawData("a"), StructuredData{"v": 1}),
+			rec("2", OperationCreate, RawData("a"), StructuredData{"v": 1}),
+			rec("3", OperationDelete, RawData("a"), StructuredData{"v": 1}),
+		},
+		want: []Record{
+			rec("3", OperationDelete, RawData("a"), StructuredData{"v": 1}),
+		},
+	}, {
+		name: "update, create and delete keep the delete",
+		in: []Record{
+			rec("1", OperationUpdate, RawData("a"), StructuredData{"v": 1}),
+			rec("2", OperationCreate, RawData("a"), StructuredData{"v": 2}),
+			rec("3", OperationDelete, RawData("a"), StructuredData{"v": 2}),
+		},
+		want: []Record{
+			rec("3", OperationDelete, RawData("a"), StructuredData{"v": 2}),
+		},
+	}, {
+		name: "raw and structured keys differ",
+		in: []Record{
+			rec("1", OperationUpdate, RawData(`{"id":1}`), StructuredData{"v": 1}),
+			rec("2", OperationUpdate, StructuredData{"id": 1}, StructuredData{"v": 2}),
+		},
+		want: []Record{
+			rec("1", OperationUpdate, RawData(`{"id":1}`), StructuredData{"v": 1}),
+			rec("2", OperationUpdate, StructuredData{"id": 1}, StructuredData{"v": 2}),
+		},
+	}, {
+		name: "bytes and base64 string keys differ",
+		in: []Record{
+			rec("1", OperationCreate, StructuredData{"id": []byte("foo")}, StructuredData{"v": 1}),
+			rec("2", OperationDelete, StructuredData{"id": "Zm9v"}, StructuredData{"v": 1}),
+		},
+		want: []Record{
+			rec("1", OperationCreate, StructuredData{"id": []byte("foo")}, StructuredData{"v": 1}),
+			rec("2", OperationDelete, StructuredData{"id": "Zm9v"}, StructuredData{"v": 1}),
+		},
+	}, {
+		name: "NaN, time and string keys differ",
+		in: []Record{
+			rec("1", OperationCreate, StructuredData{"id": math.NaN()}, StructuredData{"v": 1}),
+			rec("2", OperationCreate, StructuredData{"id": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}, StructuredData{"v": 2}),
+			rec("3", OperationDelete, StructuredData{"id": "NaN"}, StructuredData{"v": 1}),
+			rec("4", OperationDelete, StructuredData{"id": "2024-01-02T03:04:05Z"}, StructuredData{"v": 2}),
+		},
+		want: []Record{
+			rec("1", OperationCreate, StructuredData{"id": math.NaN()}, StructuredData{"v": 1}),
+			rec("2", OperationCreate, StructuredData{"id": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}, StructuredData{"v": 2}),
+			rec("3", OperationDelete, StructuredData{"id": "NaN"}, StructuredData{"v": 1}),
+			rec("4", OperationDelete, StructuredData{"id": "2024-01-02T03:04:05Z"}, StructuredData{"v": 2}),
+		},
+	}, {
+		name: "records without key are kept",
+		in: []Record{
+			rec("1", OperationCreate, nil, StructuredData{"v": 1}),
+			rec("2", OperationCreate, nil, StructuredData{"v": 1}),
+		},
+		want: []Record{
+			rec("1", OperationCreate, nil, StructuredData{"v": 1}),
+			rec("2", OperationCreate, nil, StructuredData{"v": 1}),
+		},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Compact(tc.in)
+			if diff := cmp.Diff(tc.want, got, exactRecord, cmpopts.EquateNaNs()); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"encoding/base64"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// CSVNestedMode defines how nested values (maps and slices) are written to a
+// CSV cell.
+type CSVNestedMode int
+
+const (
+	// CSVNestedJSON writes nested values as JSON encoded strings. This is the
+	// default.
+	CSVNestedJSON CSVNestedMode = iota
+	// CSVNestedFlatten flattens nested maps into separate columns, named by
+	// joining the field names with a dot (e.g. "address.city"). Slices are
+	// still written as JSON encoded strings.
+	CSVNestedFlatten
+)
+
+// CSVOptions customize the output of CSVEncoder.
+type CSVOptions struct {
+	// Columns defines the data columns and their order. When using
+	// CSVNestedFlatten, nested fields are referenced using dotted names. If
+	// empty, the columns are the sorted field names of the first encoded
+	// record that contains data. Records without data (e.g. deletes that
+	// only contain a key) are held back until the columns are known.
+	Columns []string
+	// Header controls if a header row with the column names is written
+	// before the first record.
+	Header bool
+	// Null is written in place of nil and missing values.
+	Null string
+	// Comma is the field delimiter. Defaults to ','. Use '\t' to produce TSV.
+	Comma rune
+	// Nested defines how nested values are written.
+	Nested CSVNestedMode
+	// OperationColumn is the name of a column containing the record
+	// operation. If empty, the operation is not written. The column is
+	// written before the data columns.
+	OperationColumn string
+	// MetadataColumns are metadata keys written as additional columns after
+	// the data columns. The metadata key is used as the column name.
+	MetadataColumns []string
+}
+
+// CSVEncoder writes records with StructuredData payloads to an output stream
+// as CSV rows. The row is populated from Payload.After, or Payload.Before if
+// Payload.After is empty (e.g. deletes). Records containing RawData can't be
+// encoded. Rows are buffered, Flush needs to be called after the last record
+// is encoded.
+type CSVEncoder struct {
+	w       *csv.Writer
+	options CSVOptions
+	started bool
+	// pending contains records without data encoded before the columns were
+	// detected.
+	pending []Record
+}
+
+// NewCSVEncoder returns a new encoder that writes to w.
+func NewCSVEncoder(w io.Writer, options CSVOptions) *CSVEncoder {
+	cw := csv.NewWriter(w)
+	if options.Comma != 0 {
+		cw.Comma = options.Comma
+	}
+	return &CSVEncoder{
+		w:       cw,
+		options: options,
+	}
+}
+
+// Encode writes r as a CSV row to the stream. If this is the first record and
+// the header is enabled, the header row is written first.
+func (e *CSVEncoder) Encode(r Record) error {
+	fields, err := e.recordFields(r)
+	if err != nil {
+		return err
+	}
+
+	if !e.started {
+		if len(e.options.Columns) == 0 && len(fields) == 0 {
+			// columns can't be detected yet, wait for a record with data
+			e.pending = append(e.pending, r)
+			return nil
+		}
+		if err := e.start(fields); err != nil {
+			return err
+		}
+	}
+	return e.writeRow(r, fields)
+}
+
+// start detects the columns if needed, writes the header and the pending
+// records.
+func (e *CSVEncoder) start(fields map[string]any) error {
+	e.started = true
+	if len(e.options.Columns) == 0 {
+		e.options.Columns = make([]string, 0, len(fields))
+		for k := range fields {
+			e.options.Columns = append(e.options.Columns, k)
+		}
+		sort.Strings(e.options.Columns)
+	}
+	if e.options.Header {
+		err := e.w.Write(e.header())
+		if err != nil {
+			return fmt.Errorf("failed to write CSV header: %w", err)
+		}
+	}
+
+	pending := e.pending
+	e.pending = nil
+	for _, r := range pending {
+		if err := e.writeRow(r, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordFields returns the fields of the data in the record keyed by their
+// column name.
+func (e *CSVEncoder) recordFields(r Record) (map[string]any, error) {
+	data := r.Payload.After
+	if data == nil {
+		data = r.Payload.Before
+	}
+	switch data := data.(type) {
+	case StructuredData:
+		return e.fields(data), nil
+	case nil:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to encode record as CSV: %w", ErrNotStructuredData)
+	}
+}
+
+func (e *CSVEncoder) writeRow(r Record, fields map[string]any) error {
+	row := make([]string, 0, len(e.options.Columns)+len(e.options.MetadataColumns)+1)
+	if e.options.OperationColumn != "" {
+		row = append(row, r.Operation.String())
+	}
+	for _, col := range e.options.Columns {
+		v, ok := fields[col]
+		if !ok {
+			row = append(row, e.options.Null)
+			continue
+		}
+		cell, err := e.format(v)
+		if err != nil {
+			return fmt.Errorf("failed to encode column %q: %w", col, err)
+		}
+		row = append(row, cell)
+	}
+	for _, key := range e.options.MetadataColumns {
+		v, ok := r.Metadata[key]
+		if !ok {
+			v = e.options.Null
+		}
+		row = append(row, v)
+	}
+
+	err := e.w.Write(row)
+	if err != nil {
+		return fmt.Errorf("failed to write CSV row: %w", err)
+	}
+	return nil
+}
+
+// Flush writes any buffered rows to the underlying writer. If no encoded
+// record contained data, the held back records are written without data
+// columns.
+func (e *CSVEncoder) Flush() error {
+	if !e.started && len(e.pending) > 0 {
+		if err := e.start(nil); err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	if err := e.w.Error(); err != nil {
+		return fmt.Errorf("failed to flush CSV rows: %w", err)
+	}
+	return nil
+}
+
+func (e *CSVEncoder) header() []string {
+	header := make([]string, 0, len(e.options.Columns)+len(e.options.MetadataColumns)+1)
+	if e.options.OperationColumn != "" {
+		header = append(header, e.options.OperationColumn)
+	}
+	header = append(header, e.options.Columns...)
+	header = append(header, e.options.MetadataColumns...)
+	return header
+}
+
+// fields returns the values of the data keyed by their column name.
+func (e *CSVEncoder) fields(data StructuredData) map[string]any {
+	if e.options.Nested != CSVNestedFlatten {
+		return data
+	}
+	fields := make(map[string]any, len(data))
+	csvFlatten(fields, "", data)
+	return fields
+}
+
+func csvFlatten(dst map[string]any, prefix string, m map[string]any) {
+	for k, v := range m {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+		if nested, ok := asMap(v); ok {
+			csvFlatten(dst, k, nested)
+			continue
+		}
+		dst[k] = v
+	}
+}
+
+func (e *CSVEncoder) format(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return e.options.Null, nil
+	case string:
+		return v, nil
+	case []byte:
+		if v == nil {
+			return e.options.Null, nil
+		}
+		return base64.StdEncoding.EncodeToString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case json.Number:
+		return v.String(), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() { //nolint:exhaustive // other kinds are JSON encoded
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Map, reflect.Slice, reflect.Pointer:
+		if rv.IsNil() {
+			return e.options.Null, nil
+		}
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode value as JSON: %w", err)
+	}
+	return string(b), nil
+}
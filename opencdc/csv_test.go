@@ -0,0 +1,136 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestCSVEncoder(t *testing.T) {
+	records := []Record{
+		{
+			Operation: OperationCreate,
+			Metadata:  Metadata{MetadataCollection: "users"},
+			Payload: Change{After: StructuredData{
+				"id":      1,
+				"name":    "foo, bar",
+				"active":  true,
+				"score":   1.5,
+				"address": map[string]any{"city": "Berlin", "zip": "10115"},
+				"tags":    []any{"a", "b"},
+			}},
+		},
+		{
+			Operation: OperationDelete,
+			Payload: Change{Before: StructuredData{
+				"id":   2,
+				"name": nil,
+			}},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		options CSVOptions
+		want    string
+	}{{
+		name:    "default columns",
+		options: CSVOptions{},
+		want: `true,"{""city"":""Berlin"",""zip"":""10115""}",1,"foo, bar",1.5,"[""a"",""b""]"
+,,2,,,
+`,
+	}, {
+		name: "header, operation, metadata and null",
+		options: CSVOptions{
+			Columns:         []string{"id", "name"},
+			Header:          true,
+			Null:            "NULL",
+			OperationColumn: "op",
+			MetadataColumns: []string{MetadataCollection},
+		},
+		want: `op,id,name,opencdc.collection
+create,1,"foo, bar",users
+delete,2,NULL,NULL
+`,
+	}, {
+		name: "flatten nested as TSV",
+		options: CSVOptions{
+			Columns: []string{"id", "address.city", "address.zip", "tags"},
+			Header:  true,
+			Comma:   '\t',
+			Nested:  CSVNestedFlatten,
+		},
+		want: `id	address.city	address.zip	tags
+1	Berlin	10115	"[""a"",""b""]"
+2			
+`,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			var buf bytes.Buffer
+			enc := NewCSVEncoder(&buf, tc.options)
+			for _, r := range records {
+				is.NoErr(enc.Encode(r))
+			}
+			is.NoErr(enc.Flush())
+			is.Equal(buf.String(), tc.want)
+		})
+	}
+}
+
+func TestCSVEncoder_RawData(t *testing.T) {
+	is := is.New(t)
+	enc := NewCSVEncoder(&bytes.Buffer{}, CSVOptions{})
+	err := enc.Encode(Record{Payload: Change{After: RawData("foo")}})
+	is.True(errors.Is(err, ErrNotStructuredData))
+}
+
+func TestCSVEncoder_FirstRecordWithoutData(t *testing.T) {
+	is := is.New(t)
+	var buf bytes.Buffer
+	enc := NewCSVEncoder(&buf, CSVOptions{Header: true, OperationColumn: "op"})
+
+	is.NoErr(enc.Encode(Record{
+		Operation: OperationDelete,
+		Key:       StructuredData{"id": 1},
+	}))
+	is.NoErr(enc.Encode(Record{
+		Operation: OperationCreate,
+		Payload:   Change{After: StructuredData{"id": 2, "name": "foo"}},
+	}))
+	is.NoErr(enc.Flush())
+
+	is.Equal(buf.String(), `op,id,name
+delete,,
+create,2,foo
+`)
+}
+
+func TestCSVEncoder_OnlyRecordsWithoutData(t *testing.T) {
+	is := is.New(t)
+	var buf bytes.Buffer
+	enc := NewCSVEncoder(&buf, CSVOptions{Header: true, OperationColumn: "op"})
+
+	is.NoErr(enc.Encode(Record{Operation: OperationDelete}))
+	is.NoErr(enc.Flush())
+
+	is.Equal(buf.String(), "op\ndelete\n")
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"reflect"
 
 	opencdcv1 "github.com/conduitio/conduit-commons/proto/opencdc/v1"
 	"github.com/goccy/go-json"
@@ -30,6 +31,11 @@ type Data interface {
 	isData() // Ensure structs outside of this package can't implement this interface.
 	Bytes() []byte
 	Clone() Data
+	// Size returns an estimate of the serialized size of the data in bytes,
+	// i.e. of len(Bytes()), without serializing it. RawData is serialized as
+	// is, StructuredData as JSON (where byte slices are base64 encoded), so
+	// size limits mean the same for both.
+	Size() int
 	ToProto(*opencdcv1.Data) error
 }
 
@@ -72,6 +78,88 @@ func (d StructuredData) Clone() Data {
 	return StructuredData(cloned)
 }
 
+// DeepCloneValue returns a deep copy of maps and slices contained in v, e.g. a
+// value stored in StructuredData. Maps and slices of any type (e.g.
+// map[string]string or []string) are copied and keep their type, other values
+// are returned as is.
+func DeepCloneValue(v any) any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case StructuredData:
+		if v == nil {
+			return v
+		}
+		return StructuredData(DeepCloneValue(map[string]any(v)).(map[string]any))
+	case map[string]any:
+		if v == nil {
+			return v
+		}
+		m := make(map[string]any, len(v))
+		for k, vv := range v {
+			m[k] = DeepCloneValue(vv)
+		}
+		return m
+	case []any:
+		if v == nil {
+			return v
+		}
+		s := make([]any, len(v))
+		for i, vv := range v {
+			s[i] = DeepCloneValue(vv)
+		}
+		return s
+	default:
+		return deepCloneReflect(reflect.ValueOf(v)).Interface()
+	}
+}
+
+// deepCloneReflect returns a deep copy of maps, slices and arrays of any type.
+func deepCloneReflect(rv reflect.Value) reflect.Value {
+	switch rv.Kind() { //nolint:exhaustive // other kinds are not containers
+	case reflect.Interface:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.New(rv.Type()).Elem()
+		out.Set(deepCloneReflect(rv.Elem()))
+		return out
+	case reflect.Map:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), deepCloneReflect(iter.Value()))
+		}
+		return out
+	case reflect.Slice:
+		if rv.IsNil() {
+			return rv
+		}
+		out := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			out.Index(i).Set(deepCloneReflect(rv.Index(i)))
+		}
+		return out
+	case reflect.Array:
+		out := reflect.New(rv.Type()).Elem()
+		for i := 0; i < rv.Len(); i++ {
+			out.Index(i).Set(deepCloneReflect(rv.Index(i)))
+		}
+		return out
+	default:
+		return rv
+	}
+}
+
+// Size returns an estimate of the size of the data when serialized as JSON,
+// which is what Bytes returns.
+func (d StructuredData) Size() int {
+	return estimateSize(map[string]any(d))
+}
+
 // RawData contains unstructured data in form of a byte slice.
 type RawData []byte
 
@@ -85,6 +173,12 @@ func (d RawData) Clone() Data {
 	return RawData(bytes.Clone(d))
 }
 
+// Size returns the length of the data, which is the exact size of what Bytes
+// returns.
+func (d RawData) Size() int {
+	return len(d)
+}
+
 func (d RawData) MarshalJSON(ctx context.Context) ([]byte, error) {
 	if ctx != nil {
 		s := ctx.Value(jsonMarshalOptionsCtxKey{})
@@ -0,0 +1,227 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// Debezium operation codes as used in the "op" field of a change event.
+const (
+	debeziumOpCreate = "c"
+	debeziumOpUpdate = "u"
+	debeziumOpDelete = "d"
+	debeziumOpRead   = "r"
+)
+
+type debeziumEnvelope struct {
+	Before Data           `json:"before"`
+	After  Data           `json:"after"`
+	Op     string         `json:"op"`
+	Source debeziumSource `json:"source"`
+	TsMs   int64          `json:"ts_ms,omitempty"`
+}
+
+// debeziumSource is the source block of a Debezium change event. Only the
+// fields that have an equivalent in the OpenCDC metadata are populated.
+type debeziumSource struct {
+	Version    string `json:"version,omitempty"`
+	Connector  string `json:"connector,omitempty"`
+	Name       string `json:"name,omitempty"`
+	TsMs       int64  `json:"ts_ms,omitempty"`
+	Snapshot   string `json:"snapshot,omitempty"`
+	Table      string `json:"table,omitempty"`
+	Collection string `json:"collection,omitempty"`
+}
+
+// DebeziumSerializer is a RecordSerializer that serializes records into the
+// JSON representation of a Debezium change event envelope (without the
+// schema). The record operation is mapped to the Debezium op codes (c, u, d
+// and r for snapshots), Payload.Before and Payload.After are mapped to the
+// fields "before" and "after". The source block is populated from metadata:
+//   - opencdc.collection is mapped to source.table,
+//   - opencdc.createdAt is mapped to source.ts_ms,
+//   - conduit.source.plugin.name is mapped to source.connector,
+//   - conduit.source.plugin.version is mapped to source.version,
+//   - conduit.source.connector.id is mapped to source.name.
+//
+// The field ts_ms is populated from opencdc.readAt. The record key is not part
+// of the envelope and needs to be serialized separately.
+type DebeziumSerializer JSONMarshalOptions
+
+func (s DebeziumSerializer) Serialize(r Record) ([]byte, error) {
+	op, err := debeziumOp(r.Operation)
+	if err != nil {
+		return nil, err
+	}
+
+	env := debeziumEnvelope{
+		Before: r.Payload.Before,
+		After:  r.Payload.After,
+		Op:     op,
+		Source: debeziumSource{
+			Version:   r.Metadata[MetadataConduitSourcePluginVersion],
+			Connector: r.Metadata[MetadataConduitSourcePluginName],
+			Name:      r.Metadata[MetadataConduitSourceConnectorID],
+			Snapshot:  "false",
+			Table:     r.Metadata[MetadataCollection],
+		},
+	}
+	if r.Operation == OperationSnapshot {
+		env.Source.Snapshot = "true"
+	}
+	if createdAt, err := r.Metadata.GetCreatedAt(); err == nil {
+		env.Source.TsMs = createdAt.UnixMilli()
+	}
+	if readAt, err := r.Metadata.GetReadAt(); err == nil {
+		env.TsMs = readAt.UnixMilli()
+	}
+
+	ctx := WithJSONMarshalOptions(context.Background(), (*JSONMarshalOptions)(&s))
+	defer func() {
+		// Workaround because of https://github.com/goccy/go-json/issues/499.
+		s = DebeziumSerializer{}
+	}()
+	out, err := json.MarshalContext(ctx, env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize record to Debezium envelope: %w", err)
+	}
+	return out, nil
+}
+
+func debeziumOp(op Operation) (string, error) {
+	switch op {
+	case OperationCreate:
+		return debeziumOpCreate, nil
+	case OperationUpdate:
+		return debeziumOpUpdate, nil
+	case OperationDelete:
+		return debeziumOpDelete, nil
+	case OperationSnapshot:
+		return debeziumOpRead, nil
+	default:
+		return "", fmt.Errorf("operation %q: %w", op, ErrUnknownOperation)
+	}
+}
+
+// DebeziumDeserializer is a RecordDeserializer that parses the JSON
+// representation of a Debezium change event envelope into a record. It
+// accepts the envelope on its own or wrapped in an object with the fields
+// "schema" and "payload", as produced by the Kafka Connect JSON converter. The
+// fields are mapped back in the same way as DebeziumSerializer maps them,
+// source.collection is accepted as an alternative to source.table.
+type DebeziumDeserializer struct {
+	// RawDataAsString indicates that string values in the fields "before" and
+	// "after" should be used as RawData without conversion. If set to false,
+	// they are decoded as base64 encoded strings.
+	RawDataAsString bool
+}
+
+func (d DebeziumDeserializer) Deserialize(b []byte) (Record, error) {
+	var raw struct {
+		Before  json.RawMessage `json:"before"`
+		After   json.RawMessage `json:"after"`
+		Op      string          `json:"op"`
+		Source  debeziumSource  `json:"source"`
+		TsMs    int64           `json:"ts_ms"`
+		Payload json.RawMessage `json:"payload"`
+	}
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to parse Debezium envelope: %w", err)
+	}
+	if raw.Op == "" && len(raw.Payload) > 0 {
+		// Envelope is wrapped in a schema/payload object.
+		return d.Deserialize(raw.Payload)
+	}
+
+	var r Record
+	switch raw.Op {
+	case debeziumOpCreate:
+		r.Operation = OperationCreate
+	case debeziumOpUpdate:
+		r.Operation = OperationUpdate
+	case debeziumOpDelete:
+		r.Operation = OperationDelete
+	case debeziumOpRead:
+		r.Operation = OperationSnapshot
+	default:
+		return Record{}, fmt.Errorf("debezium operation %q: %w", raw.Op, ErrUnknownOperation)
+	}
+
+	r.Payload.Before, err = d.parseData(raw.Before)
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to parse before: %w", err)
+	}
+	r.Payload.After, err = d.parseData(raw.After)
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to parse after: %w", err)
+	}
+
+	r.Metadata = Metadata{}
+	setIfNotEmpty := func(key, value string) {
+		if value != "" {
+			r.Metadata[key] = value
+		}
+	}
+	setIfNotEmpty(MetadataConduitSourcePluginVersion, raw.Source.Version)
+	setIfNotEmpty(MetadataConduitSourcePluginName, raw.Source.Connector)
+	setIfNotEmpty(MetadataConduitSourceConnectorID, raw.Source.Name)
+	setIfNotEmpty(MetadataCollection, raw.Source.Collection)
+	setIfNotEmpty(MetadataCollection, raw.Source.Table)
+	if raw.Source.TsMs != 0 {
+		r.Metadata.SetCreatedAt(time.UnixMilli(raw.Source.TsMs))
+	}
+	if raw.TsMs != 0 {
+		r.Metadata.SetReadAt(time.UnixMilli(raw.TsMs))
+	}
+
+	return r, nil
+}
+
+func (d DebeziumDeserializer) parseData(b []byte) (Data, error) {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 || bytes.Equal(b, []byte("null")) {
+		return nil, nil //nolint:nilnil // no data is a valid value
+	}
+	if b[0] != '"' {
+		var data StructuredData
+		err := json.Unmarshal(b, &data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal structured data: %w", err)
+		}
+		return data, nil
+	}
+
+	var str string
+	err := json.Unmarshal(b, &str)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal raw data: %w", err)
+	}
+	if d.RawDataAsString {
+		return RawData(str), nil
+	}
+	data, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode raw data: %w", err)
+	}
+	return RawData(data), nil
+}
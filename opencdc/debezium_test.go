@@ -0,0 +1,175 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/matryer/is"
+)
+
+func TestDebeziumSerializer(t *testing.T) {
+	createdAt := time.UnixMilli(1700000000123)
+	readAt := time.UnixMilli(1700000000456)
+
+	metadata := Metadata{
+		MetadataCollection:                 "users",
+		MetadataConduitSourcePluginName:    "postgres",
+		MetadataConduitSourcePluginVersion: "v0.1.0",
+		MetadataConduitSourceConnectorID:   "pipeline:source",
+	}
+	metadata.SetCreatedAt(createdAt)
+	metadata.SetReadAt(readAt)
+
+	testCases := []struct {
+		name       string
+		serializer DebeziumSerializer
+		record     Record
+		want       string
+	}{{
+		name:       "create",
+		serializer: DebeziumSerializer{},
+		record: Record{
+			Operation: OperationCreate,
+			Metadata:  metadata,
+			Payload:   Change{After: StructuredData{"id": 1, "name": "john"}},
+		},
+		want: `{"before":null,"after":{"id":1,"name":"john"},"op":"c","source":{"version":"v0.1.0","connector":"postgres","name":"pipeline:source","ts_ms":1700000000123,"snapshot":"false","table":"users"},"ts_ms":1700000000456}`,
+	}, {
+		name:       "update",
+		serializer: DebeziumSerializer{},
+		record: Record{
+			Operation: OperationUpdate,
+			Payload: Change{
+				Before: StructuredData{"id": 1, "name": "john"},
+				After:  StructuredData{"id": 1, "name": "jane"},
+			},
+		},
+		want: `{"before":{"id":1,"name":"john"},"after":{"id":1,"name":"jane"},"op":"u","source":{"snapshot":"false"}}`,
+	}, {
+		name:       "delete",
+		serializer: DebeziumSerializer{},
+		record: Record{
+			Operation: OperationDelete,
+			Payload:   Change{Before: StructuredData{"id": 1}},
+		},
+		want: `{"before":{"id":1},"after":null,"op":"d","source":{"snapshot":"false"}}`,
+	}, {
+		name:       "snapshot with raw data",
+		serializer: DebeziumSerializer{RawDataAsString: true},
+		record: Record{
+			Operation: OperationSnapshot,
+			Payload:   Change{After: RawData("raw")},
+		},
+		want: `{"before":null,"after":"raw","op":"r","source":{"snapshot":"true"}}`,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			got, err := tc.serializer.Serialize(tc.record)
+			is.NoErr(err)
+			is.Equal(cmp.Diff(tc.want, string(got)), "")
+		})
+	}
+}
+
+func TestDebeziumSerializer_UnknownOperation(t *testing.T) {
+	is := is.New(t)
+	_, err := DebeziumSerializer{}.Serialize(Record{Operation: Operation(7)})
+	is.True(errors.Is(err, ErrUnknownOperation))
+}
+
+func TestDebeziumDeserializer(t *testing.T) {
+	wantMetadata := Metadata{MetadataCollection: "users"}
+	wantMetadata.SetCreatedAt(time.UnixMilli(1700000000123))
+	wantMetadata.SetReadAt(time.UnixMilli(1700000000456))
+
+	testCases := []struct {
+		name string
+		have string
+		want Record
+	}{{
+		name: "update",
+		have: `{"before":{"id":1,"name":"john"},"after":{"id":1,"name":"jane"},"op":"u","source":{"ts_ms":1700000000123,"table":"users"},"ts_ms":1700000000456}`,
+		want: Record{
+			Operation: OperationUpdate,
+			Metadata:  wantMetadata,
+			Payload: Change{
+				Before: StructuredData{"id": 1.0, "name": "john"},
+				After:  StructuredData{"id": 1.0, "name": "jane"},
+			},
+		},
+	}, {
+		name: "wrapped in schema and payload",
+		have: `{"schema":{"type":"struct"},"payload":{"before":{"id":1},"after":null,"op":"d","source":{"ts_ms":1700000000123,"collection":"users"},"ts_ms":1700000000456}}`,
+		want: Record{
+			Operation: OperationDelete,
+			Metadata:  wantMetadata,
+			Payload:   Change{Before: StructuredData{"id": 1.0}},
+		},
+	}, {
+		name: "snapshot with raw data",
+		have: `{"after":"cmF3","op":"r","source":{}}`,
+		want: Record{
+			Operation: OperationSnapshot,
+			Metadata:  Metadata{},
+			Payload:   Change{After: RawData("raw")},
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			got, err := DebeziumDeserializer{}.Deserialize([]byte(tc.have))
+			is.NoErr(err)
+			is.Equal(cmp.Diff(tc.want, got, exactRecord), "")
+		})
+	}
+}
+
+func TestDebezium_RoundTrip(t *testing.T) {
+	is := is.New(t)
+
+	want := Record{
+		Operation: OperationUpdate,
+		Metadata: Metadata{
+			MetadataCollection:                 "users",
+			MetadataConduitSourcePluginName:    "postgres",
+			MetadataConduitSourcePluginVersion: "v0.1.0",
+			MetadataConduitSourceConnectorID:   "pipeline:source",
+		},
+		Payload: Change{
+			Before: RawData("before"),
+			After:  StructuredData{"id": 1.0, "name": "jane"},
+		},
+	}
+	want.Metadata.SetCreatedAt(time.UnixMilli(1700000000123))
+
+	b, err := DebeziumSerializer{RawDataAsString: true}.Serialize(want)
+	is.NoErr(err)
+	got, err := DebeziumDeserializer{RawDataAsString: true}.Deserialize(b)
+	is.NoErr(err)
+	is.Equal(cmp.Diff(want, got, exactRecord), "")
+}
+
+func TestDebeziumDeserializer_UnknownOperation(t *testing.T) {
+	is := is.New(t)
+	_, err := DebeziumDeserializer{}.Deserialize([]byte(`{"op":"t","source":{}}`))
+	is.True(errors.Is(err, ErrUnknownOperation))
+}
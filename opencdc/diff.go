@@ -0,0 +1,272 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate stringer -type=DiffType -linecomment
+
+package opencdc
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	DiffAdded    DiffType = iota + 1 // added
+	DiffRemoved                      // removed
+	DiffModified                     // modified
+)
+
+// DiffType describes how a field changed between two values.
+type DiffType int
+
+// FieldDiff describes a change of a single field between two StructuredData
+// values.
+type FieldDiff struct {
+	// Path is the path of the changed field relative to the compared data
+	// (e.g. .customer.address.city or .items[1]). Prepend the path with the
+	// record field that contains the data (e.g. .Payload.After) to get a path
+	// that can be parsed with ParsePath.
+	Path string
+	// Type defines how the field changed.
+	Type DiffType
+	// Old is the value before the change. It is nil for added fields.
+	Old any
+	// New is the value after the change. It is nil for removed fields.
+	New any
+}
+
+// Diff compares Before and After and returns the list of fields that changed.
+// If any of the values is nil, it is treated as empty StructuredData. If any
+// of the values is RawData, the function returns ErrNotStructuredData.
+func (c Change) Diff() ([]FieldDiff, error) {
+	before, err := diffStructuredData(c.Before)
+	if err != nil {
+		return nil, fmt.Errorf("before: %w", err)
+	}
+	after, err := diffStructuredData(c.After)
+	if err != nil {
+		return nil, fmt.Errorf("after: %w", err)
+	}
+	return Diff(before, after), nil
+}
+
+func diffStructuredData(d Data) (StructuredData, error) {
+	switch d := d.(type) {
+	case nil:
+		return nil, nil
+	case StructuredData:
+		return d, nil
+	default:
+		return nil, ErrNotStructuredData
+	}
+}
+
+// Diff recursively compares two StructuredData values and returns the list of
+// fields that were added, removed or modified, sorted by path. Nested maps are
+// compared field by field, slices are compared element by element. Numbers
+// are compared by value regardless of their type (e.g. int(1) equals
+// float64(1)).
+func Diff(before, after StructuredData) []FieldDiff {
+	return diffMaps("", before, after, nil)
+}
+
+func diffValues(path string, before, after any, diffs []FieldDiff) []FieldDiff {
+	if bm, ok := asMap(before); ok {
+		if am, ok := asMap(after); ok {
+			return diffMaps(path, bm, am, diffs)
+		}
+	}
+	if bs, ok := asSlice(before); ok {
+		if as, ok := asSlice(after); ok {
+			return diffSlices(path, bs, as, diffs)
+		}
+	}
+	if !valuesEqual(before, after) {
+		diffs = append(diffs, FieldDiff{Path: path, Type: DiffModified, Old: before, New: after})
+	}
+	return diffs
+}
+
+func diffMaps(path string, before, after map[string]any, diffs []FieldDiff) []FieldDiff {
+	keys := make([]string, 0, len(before)+len(after))
+	for k := range before {
+		keys = append(keys, k)
+	}
+	for k := range after {
+		if _, ok := before[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		fieldPath := path + pathField{name: k}.String()
+		bv, bok := before[k]
+		av, aok := after[k]
+		switch {
+		case !bok:
+			diffs = append(diffs, FieldDiff{Path: fieldPath, Type: DiffAdded, New: av})
+		case !aok:
+			diffs = append(diffs, FieldDiff{Path: fieldPath, Type: DiffRemoved, Old: bv})
+		default:
+			diffs = diffValues(fieldPath, bv, av, diffs)
+		}
+	}
+	return diffs
+}
+
+func diffSlices(path string, before, after []any, diffs []FieldDiff) []FieldDiff {
+	for i := 0; i < max(len(before), len(after)); i++ {
+		elemPath := path + pathField{index: i, isIndex: true}.String()
+		switch {
+		case i >= len(before):
+			diffs = append(diffs, FieldDiff{Path: elemPath, Type: DiffAdded, New: after[i]})
+		case i >= len(after):
+			diffs = append(diffs, FieldDiff{Path: elemPath, Type: DiffRemoved, Old: before[i]})
+		default:
+			diffs = diffValues(elemPath, before[i], after[i], diffs)
+		}
+	}
+	return diffs
+}
+
+// asMap returns v as a map[string]any if v is a map with string keys.
+func asMap(v any) (map[string]any, bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		return v, true
+	case StructuredData:
+		return v, true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	m := make(map[string]any, rv.Len())
+	iter := rv.MapRange()
+	for iter.Next() {
+		m[iter.Key().String()] = iter.Value().Interface()
+	}
+	return m, true
+}
+
+// asSlice returns v as a []any if v is a slice or array. Byte slices are not
+// regarded as slices, as they represent a single binary value.
+func asSlice(v any) ([]any, bool) {
+	switch v := v.(type) {
+	case []any:
+		return v, true
+	case []byte:
+		return nil, false
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+	s := make([]any, rv.Len())
+	for i := range s {
+		s[i] = rv.Index(i).Interface()
+	}
+	return s, true
+}
+
+// valuesEqual reports whether two values are equal. Numbers are compared by
+// value regardless of their type, all other values are compared using
+// reflect.DeepEqual.
+func valuesEqual(a, b any) bool {
+	if an, ok := asNumber(a); ok {
+		if bn, ok := asNumber(b); ok {
+			return an.equal(bn)
+		}
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// number is a normalized representation of a numeric value. Integers that fit
+// into int64 are stored in i, unsigned integers that don't fit in u and floats
+// in f.
+type number struct {
+	kind reflect.Kind // reflect.Int64, reflect.Uint64 or reflect.Float64
+	i    int64
+	u    uint64
+	f    float64
+}
+
+func asNumber(v any) (number, bool) {
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return number{kind: reflect.Int64, i: i}, true
+		}
+		if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+			return number{kind: reflect.Uint64, u: u}, true
+		}
+		f, err := n.Float64()
+		if err != nil {
+			return number{}, false
+		}
+		v = f
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() { //nolint:exhaustive // only numeric kinds are relevant
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return number{kind: reflect.Int64, i: rv.Int()}, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := rv.Uint()
+		if u <= math.MaxInt64 {
+			return number{kind: reflect.Int64, i: int64(u)}, true
+		}
+		return number{kind: reflect.Uint64, u: u}, true
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if rv.Kind() == reflect.Float32 {
+			// Use the shortest decimal representation of the float32, so
+			// float32(1.2) equals float64(1.2).
+			f = shortestFloat32(float32(f))
+		}
+		if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+			return number{kind: reflect.Int64, i: int64(f)}, true
+		}
+		return number{kind: reflect.Float64, f: f}, true
+	default:
+		return number{}, false
+	}
+}
+
+func (n number) equal(o number) bool {
+	if n.kind != o.kind {
+		return false
+	}
+	switch n.kind { //nolint:exhaustive // only normalized kinds are possible
+	case reflect.Int64:
+		return n.i == o.i
+	case reflect.Uint64:
+		return n.u == o.u
+	default:
+		return n.f == o.f
+	}
+}
+
+// shortestFloat32 converts f to a float64 with the same shortest decimal
+// representation as f.
+func shortestFloat32(f float32) float64 {
+	out, _ := strconv.ParseFloat(strconv.FormatFloat(float64(f), 'g', -1, 32), 64)
+	return out
+}
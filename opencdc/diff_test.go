@@ -0,0 +1,124 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/matryer/is"
+)
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		name   string
+		before StructuredData
+		after  StructuredData
+		want   []FieldDiff
+	}{{
+		name:   "equal",
+		before: StructuredData{"id": 1, "name": "john", "tags": []any{"a"}},
+		after:  StructuredData{"id": 1.0, "name": "john", "tags": []string{"a"}},
+		want:   nil,
+	}, {
+		name:   "nil and empty",
+		before: nil,
+		after:  StructuredData{},
+		want:   nil,
+	}, {
+		name:   "added, removed and modified",
+		before: StructuredData{"id": 1, "name": "john", "age": 30},
+		after:  StructuredData{"id": 1, "name": "jane", "email": "jane@example.com"},
+		want: []FieldDiff{
+			{Path: ".age", Type: DiffRemoved, Old: 30},
+			{Path: ".email", Type: DiffAdded, New: "jane@example.com"},
+			{Path: ".name", Type: DiffModified, Old: "john", New: "jane"},
+		},
+	}, {
+		name: "nested maps",
+		before: StructuredData{
+			"customer": map[string]any{"address": StructuredData{"city": "Amsterdam", "zip": "1000"}},
+		},
+		after: StructuredData{
+			"customer": map[string]any{"address": map[string]any{"city": "Ljubljana", "zip": "1000"}},
+		},
+		want: []FieldDiff{
+			{Path: ".customer.address.city", Type: DiffModified, Old: "Amsterdam", New: "Ljubljana"},
+		},
+	}, {
+		name:   "slices",
+		before: StructuredData{"items": []any{1, map[string]any{"a": 1}, 3}},
+		after:  StructuredData{"items": []any{1, map[string]any{"a": 2}}, "new items": []int{1}},
+		want: []FieldDiff{
+			{Path: ".items[1].a", Type: DiffModified, Old: 1, New: 2},
+			{Path: ".items[2]", Type: DiffRemoved, Old: 3},
+			{Path: `["new items"]`, Type: DiffAdded, New: []int{1}},
+		},
+	}, {
+		name:   "type change",
+		before: StructuredData{"value": map[string]any{"a": 1}},
+		after:  StructuredData{"value": "a"},
+		want: []FieldDiff{
+			{Path: ".value", Type: DiffModified, Old: map[string]any{"a": 1}, New: "a"},
+		},
+	}, {
+		name:   "numbers",
+		before: StructuredData{"f32": float32(1.2), "f64": 1.5, "int": 1, "bytes": []byte("a")},
+		after:  StructuredData{"f32": 1.2, "f64": 1.25, "int": "1", "bytes": []byte("a")},
+		want: []FieldDiff{
+			{Path: ".f64", Type: DiffModified, Old: 1.5, New: 1.25},
+			{Path: ".int", Type: DiffModified, Old: 1, New: "1"},
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			got := Diff(tc.before, tc.after)
+			is.Equal(cmp.Diff(tc.want, got), "")
+		})
+	}
+}
+
+func TestChange_Diff(t *testing.T) {
+	is := is.New(t)
+
+	got, err := Change{After: StructuredData{"id": 1}}.Diff()
+	is.NoErr(err)
+	is.Equal(got, []FieldDiff{{Path: ".id", Type: DiffAdded, New: 1}})
+
+	got, err = Change{Before: StructuredData{"id": 1}}.Diff()
+	is.NoErr(err)
+	is.Equal(got, []FieldDiff{{Path: ".id", Type: DiffRemoved, Old: 1}})
+
+	_, err = Change{Before: RawData("foo"), After: StructuredData{}}.Diff()
+	is.True(errors.Is(err, ErrNotStructuredData))
+}
+
+func TestDiff_PathsAreParsable(t *testing.T) {
+	is := is.New(t)
+
+	before := StructuredData{"a b": map[string]any{"c.d": []any{1}}}
+	after := StructuredData{"a b": map[string]any{"c.d": []any{2}}}
+	diffs := Diff(before, after)
+	is.Equal(len(diffs), 1)
+
+	p, err := ParsePath(".Payload.After" + diffs[0].Path)
+	is.NoErr(err)
+	got, err := p.Get(Record{Payload: Change{After: after}})
+	is.NoErr(err)
+	is.Equal(got, 2)
+}
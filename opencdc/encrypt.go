@@ -0,0 +1,306 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/goccy/go-json"
+)
+
+// KeyProvider provides the keys used by FieldEncryptor. Keys need to be 16, 24
+// or 32 bytes long to select AES-128, AES-192 or AES-256. To rotate keys, the
+// provider starts returning a new current key, while still providing the
+// previous keys by their ID, so that existing records can be decrypted.
+type KeyProvider interface {
+	// CurrentKey returns the ID and the key used to encrypt records.
+	CurrentKey() (id string, key []byte, err error)
+	// Key returns the key with the supplied ID. It should return
+	// ErrEncryptionKeyNotFound if the key does not exist.
+	Key(id string) ([]byte, error)
+}
+
+// StaticKeyProvider is a KeyProvider backed by a fixed set of keys.
+type StaticKeyProvider struct {
+	// CurrentID is the ID of the key used to encrypt records.
+	CurrentID string
+	// Keys contains all keys, including the current key, keyed by their ID.
+	Keys map[string][]byte
+}
+
+func (p StaticKeyProvider) CurrentKey() (string, []byte, error) {
+	key, err := p.Key(p.CurrentID)
+	if err != nil {
+		return "", nil, err
+	}
+	return p.CurrentID, key, nil
+}
+
+func (p StaticKeyProvider) Key(id string) ([]byte, error) {
+	key, ok := p.Keys[id]
+	if !ok {
+		return nil, fmt.Errorf("key %q: %w", id, ErrEncryptionKeyNotFound)
+	}
+	return key, nil
+}
+
+// FieldEncryptor encrypts and decrypts values in records using AES-GCM. It
+// encrypts values in StructuredData addressed by paths, or the whole data if
+// the path points to a Key or Payload containing RawData.
+//
+// An encrypted value in StructuredData is replaced by a base64 encoded string
+// containing the nonce and the ciphertext of the JSON encoded value. When
+// decrypted, the value is restored as decoded from JSON, so the round trip is
+// not type-exact: whole numbers are restored as int64 (including floats like
+// 12.0, which come back as int64(12)), other numbers as float64, nested maps
+// as map[string]any and slices as []any. Encrypted RawData is replaced by the nonce and the
+// ciphertext. The ID of the key and the paths of encrypted values are stored
+// in the record metadata (see MetadataEncryptionKeyID and
+// MetadataEncryptionFields), so that the record can be decrypted without
+// knowing which fields were encrypted.
+type FieldEncryptor struct {
+	keys   KeyProvider
+	fields []Path
+}
+
+// NewFieldEncryptor returns an encryptor that encrypts the values at the
+// supplied paths (e.g. ".Payload.After.ssn" or ".Payload.After"), using keys
+// from the key provider. Only the key and payload can be encrypted.
+func NewFieldEncryptor(keys KeyProvider, fields ...string) (*FieldEncryptor, error) {
+	paths := make([]Path, len(fields))
+	for i, f := range fields {
+		p, err := ParsePath(f)
+		if err != nil {
+			return nil, err
+		}
+		switch p.root { //nolint:exhaustive // other roots are invalid
+		case pathRootKey, pathRootPayloadBefore, pathRootPayloadAfter:
+		default:
+			return nil, fmt.Errorf("path %q: only the key and payload can be encrypted: %w", f, ErrInvalidPath)
+		}
+		paths[i] = p
+	}
+	return &FieldEncryptor{keys: keys, fields: paths}, nil
+}
+
+// Encrypt returns a clone of the record with the configured fields encrypted
+// using the current key. Fields that don't exist are skipped. Encrypting a
+// record that already contains encrypted fields returns an error.
+func (e *FieldEncryptor) Encrypt(r Record) (Record, error) {
+	if _, ok := r.Metadata[MetadataEncryptionKeyID]; ok {
+		return Record{}, fmt.Errorf("failed to encrypt record: %w", ErrAlreadyEncrypted)
+	}
+	id, key, err := e.keys.CurrentKey()
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to get current encryption key: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return Record{}, err
+	}
+
+	r = r.DeepClone()
+	var encrypted []string
+	for _, p := range e.fields {
+		v, err := p.Get(r)
+		if err != nil {
+			if errors.Is(err, ErrFieldNotFound) {
+				continue
+			}
+			return Record{}, fmt.Errorf("failed to encrypt record: %w", err)
+		}
+		if v == nil {
+			continue
+		}
+
+		aad := []byte(p.String())
+		if len(p.fields) == 0 {
+			raw, ok := v.(RawData)
+			if !ok {
+				return Record{}, fmt.Errorf("failed to encrypt record: path %q: only RawData can be encrypted as a whole: %w", p, ErrInvalidFieldType)
+			}
+			v = RawData(sealGCM(aead, raw, aad))
+		} else {
+			plaintext, err := json.Marshal(v)
+			if err != nil {
+				return Record{}, fmt.Errorf("failed to encrypt record: path %q: %w", p, err)
+			}
+			v = base64.StdEncoding.EncodeToString(sealGCM(aead, plaintext, aad))
+		}
+		err = p.Set(&r, v)
+		if err != nil {
+			return Record{}, fmt.Errorf("failed to encrypt record: %w", err)
+		}
+		encrypted = append(encrypted, p.String())
+	}
+
+	if len(encrypted) > 0 {
+		if r.Metadata == nil {
+			r.Metadata = Metadata{}
+		}
+		r.Metadata.SetEncryptionKeyID(id)
+		r.Metadata.SetEncryptionFields(encrypted)
+	}
+	return r, nil
+}
+
+// Decrypt returns a clone of the record with all encrypted fields decrypted.
+// The key and the fields are determined based on the record metadata, which
+// is removed from the returned record. A record without encrypted fields is
+// returned unchanged.
+func (e *FieldEncryptor) Decrypt(r Record) (Record, error) {
+	id, err := r.Metadata.GetEncryptionKeyID()
+	if err != nil {
+		return r, nil //nolint:nilerr // record is not encrypted
+	}
+	fields, err := r.Metadata.GetEncryptionFields()
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to decrypt record: %w", err)
+	}
+	key, err := e.keys.Key(id)
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to get encryption key: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return Record{}, err
+	}
+
+	r = r.DeepClone()
+	for _, f := range fields {
+		p, err := ParsePath(f)
+		if err != nil {
+			return Record{}, fmt.Errorf("failed to decrypt record: %w", err)
+		}
+		v, err := p.Get(r)
+		if err != nil {
+			return Record{}, fmt.Errorf("failed to decrypt record: %w", err)
+		}
+
+		aad := []byte(p.String())
+		if len(p.fields) == 0 {
+			raw, ok := v.(RawData)
+			if !ok {
+				return Record{}, fmt.Errorf("failed to decrypt record: path %q: expected RawData, got %T: %w", p, v, ErrDecryptionFailed)
+			}
+			plaintext, err := openGCM(aead, raw, aad)
+			if err != nil {
+				return Record{}, fmt.Errorf("failed to decrypt record: path %q: %w", p, err)
+			}
+			v = RawData(plaintext)
+		} else {
+			s, ok := v.(string)
+			if !ok {
+				return Record{}, fmt.Errorf("failed to decrypt record: path %q: expected string, got %T: %w", p, v, ErrDecryptionFailed)
+			}
+			ciphertext, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return Record{}, fmt.Errorf("failed to decrypt record: path %q: %w: %w", p, ErrDecryptionFailed, err)
+			}
+			plaintext, err := openGCM(aead, ciphertext, aad)
+			if err != nil {
+				return Record{}, fmt.Errorf("failed to decrypt record: path %q: %w", p, err)
+			}
+			// decode numbers as int64 where possible, so integers larger
+			// than 2^53 don't lose precision
+			dec := json.NewDecoder(bytes.NewReader(plaintext))
+			dec.UseNumber()
+			v = nil
+			err = dec.Decode(&v)
+			if err != nil {
+				return Record{}, fmt.Errorf("failed to decrypt record: path %q: %w: %w", p, ErrDecryptionFailed, err)
+			}
+			v = numbersToInt64(v)
+		}
+		err = p.Set(&r, v)
+		if err != nil {
+			return Record{}, fmt.Errorf("failed to decrypt record: %w", err)
+		}
+	}
+
+	delete(r.Metadata, MetadataEncryptionKeyID)
+	delete(r.Metadata, MetadataEncryptionFields)
+	return r, nil
+}
+
+// Rotate decrypts the record and encrypts the same fields again using the
+// current key. Records that are already encrypted with the current key are
+// returned unchanged.
+func (e *FieldEncryptor) Rotate(r Record) (Record, error) {
+	id, err := r.Metadata.GetEncryptionKeyID()
+	if err != nil {
+		return r, nil //nolint:nilerr // record is not encrypted
+	}
+	currentID, _, err := e.keys.CurrentKey()
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to get current encryption key: %w", err)
+	}
+	if id == currentID {
+		return r, nil
+	}
+
+	fields, err := r.Metadata.GetEncryptionFields()
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to rotate record key: %w", err)
+	}
+	r, err = e.Decrypt(r)
+	if err != nil {
+		return Record{}, err
+	}
+	reencrypt, err := NewFieldEncryptor(e.keys, fields...)
+	if err != nil {
+		return Record{}, err
+	}
+	return reencrypt.Encrypt(r)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return aead, nil
+}
+
+// sealGCM encrypts the plaintext and returns the nonce followed by the
+// ciphertext. The additional data binds the ciphertext to its location.
+func sealGCM(aead cipher.AEAD, plaintext, additionalData []byte) []byte {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	_, _ = rand.Read(nonce) // never returns an error
+	return aead.Seal(nonce, nonce, plaintext, additionalData)
+}
+
+// openGCM decrypts data produced by sealGCM.
+func openGCM(aead cipher.AEAD, data, additionalData []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short: %w", ErrDecryptionFailed)
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryptionFailed, err)
+	}
+	return plaintext, nil
+}
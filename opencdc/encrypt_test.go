@@ -0,0 +1,211 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/matryer/is"
+)
+
+func testKeyProvider() StaticKeyProvider {
+	return StaticKeyProvider{
+		CurrentID: "key-1",
+		Keys: map[string][]byte{
+			"key-1": bytes.Repeat([]byte{1}, 32),
+			"key-2": bytes.Repeat([]byte{2}, 16),
+		},
+	}
+}
+
+func TestFieldEncryptor_EncryptDecrypt(t *testing.T) {
+	is := is.New(t)
+
+	newRecord := func() Record {
+		return Record{
+			Operation: OperationCreate,
+			Metadata:  Metadata{"foo": "bar"},
+			Key:       RawData("secret-key"),
+			Payload: Change{
+				After: StructuredData{
+					"name":    "foo",
+					"ssn":     "123-45-6789",
+					"card":    map[string]any{"number": "4111111111111111", "cvv": int64(123), "limit": 1500.5},
+					"balance": 12.5,
+					// integers larger than 2^53 don't lose precision
+					"id": int64(9007199254740993),
+				},
+			},
+		}
+	}
+
+	enc, err := NewFieldEncryptor(testKeyProvider(), ".Key", ".Payload.After.ssn", ".Payload.After.card", ".Payload.After.id", ".Payload.After.missing", ".Payload.Before.ssn")
+	is.NoErr(err)
+
+	in := newRecord()
+	encrypted, err := enc.Encrypt(in)
+	is.NoErr(err)
+
+	// the original record is untouched
+	is.Equal(cmp.Diff(newRecord(), in, exactRecord), "")
+
+	is.True(!bytes.Contains(encrypted.Key.Bytes(), []byte("secret-key")))
+	after := encrypted.Payload.After.(StructuredData)
+	is.Equal(after["name"], "foo")
+	is.Equal(after["balance"], 12.5)
+	_, ok := after["ssn"].(string)
+	is.True(ok)
+	is.True(after["ssn"] != "123-45-6789")
+	_, ok = after["card"].(string)
+	is.True(ok)
+
+	keyID, err := encrypted.Metadata.GetEncryptionKeyID()
+	is.NoErr(err)
+	is.Equal(keyID, "key-1")
+	fields, err := encrypted.Metadata.GetEncryptionFields()
+	is.NoErr(err)
+	is.Equal(fields, []string{".Key", ".Payload.After.ssn", ".Payload.After.card", ".Payload.After.id"})
+
+	// decrypting doesn't require the encryptor to know the fields
+	dec, err := NewFieldEncryptor(testKeyProvider())
+	is.NoErr(err)
+	decrypted, err := dec.Decrypt(encrypted)
+	is.NoErr(err)
+	is.Equal(cmp.Diff(newRecord(), decrypted, exactRecord), "")
+}
+
+func TestFieldEncryptor_DecryptNumbers(t *testing.T) {
+	is := is.New(t)
+
+	enc, err := NewFieldEncryptor(testKeyProvider(), ".Payload.After.int", ".Payload.After.float", ".Payload.After.wholeFloat")
+	is.NoErr(err)
+
+	encrypted, err := enc.Encrypt(Record{Payload: Change{After: StructuredData{
+		"int":        int32(42),
+		"float":      12.5,
+		"wholeFloat": 12.0,
+	}}})
+	is.NoErr(err)
+	decrypted, err := enc.Decrypt(encrypted)
+	is.NoErr(err)
+
+	// the round trip is not type-exact, whole numbers are restored as int64
+	is.Equal(decrypted.Payload.After, StructuredData{
+		"int":        int64(42),
+		"float":      12.5,
+		"wholeFloat": int64(12),
+	})
+}
+
+func TestFieldEncryptor_TypedMaps(t *testing.T) {
+	is := is.New(t)
+
+	newRecord := func() Record {
+		return Record{Payload: Change{After: StructuredData{
+			"user": map[string]string{"ssn": "123-45-6789", "name": "foo"},
+		}}}
+	}
+
+	enc, err := NewFieldEncryptor(testKeyProvider(), ".Payload.After.user.ssn")
+	is.NoErr(err)
+
+	in := newRecord()
+	encrypted, err := enc.Encrypt(in)
+	is.NoErr(err)
+	// the original record is untouched
+	is.Equal(in, newRecord())
+	is.True(encrypted.Payload.After.(StructuredData)["user"].(map[string]string)["ssn"] != "123-45-6789")
+
+	encryptedCopy := encrypted.DeepClone()
+	decrypted, err := enc.Decrypt(encrypted)
+	is.NoErr(err)
+	// the encrypted record is untouched
+	is.Equal(encrypted, encryptedCopy)
+	is.Equal(decrypted.Payload.After, newRecord().Payload.After)
+}
+
+func TestFieldEncryptor_Rotate(t *testing.T) {
+	is := is.New(t)
+
+	keys := testKeyProvider()
+	enc, err := NewFieldEncryptor(keys, ".Payload.After.ssn")
+	is.NoErr(err)
+
+	want := Record{Payload: Change{After: StructuredData{"ssn": "123-45-6789"}}}
+	encrypted, err := enc.Encrypt(want)
+	is.NoErr(err)
+
+	// rotate the key
+	keys.CurrentID = "key-2"
+	enc, err = NewFieldEncryptor(keys, ".Payload.After.ssn")
+	is.NoErr(err)
+
+	// records encrypted with the old key can still be decrypted
+	got, err := enc.Decrypt(encrypted)
+	is.NoErr(err)
+	is.Equal(got.Payload.After, want.Payload.After)
+
+	rotated, err := enc.Rotate(encrypted)
+	is.NoErr(err)
+	keyID, err := rotated.Metadata.GetEncryptionKeyID()
+	is.NoErr(err)
+	is.Equal(keyID, "key-2")
+
+	// old key is no longer needed
+	delete(keys.Keys, "key-1")
+	got, err = enc.Decrypt(rotated)
+	is.NoErr(err)
+	is.Equal(got.Payload.After, want.Payload.After)
+
+	_, err = enc.Decrypt(encrypted)
+	is.True(errors.Is(err, ErrEncryptionKeyNotFound))
+}
+
+func TestFieldEncryptor_Errors(t *testing.T) {
+	is := is.New(t)
+
+	_, err := NewFieldEncryptor(testKeyProvider(), ".Metadata.foo")
+	is.True(errors.Is(err, ErrInvalidPath))
+
+	enc, err := NewFieldEncryptor(testKeyProvider(), ".Payload.After", ".Key.id")
+	is.NoErr(err)
+
+	// structured data can't be encrypted as a whole
+	_, err = enc.Encrypt(Record{Payload: Change{After: StructuredData{"foo": "bar"}}})
+	is.True(errors.Is(err, ErrInvalidFieldType))
+
+	encrypted, err := enc.Encrypt(Record{Key: StructuredData{"id": 1}, Payload: Change{After: RawData("foo")}})
+	is.NoErr(err)
+
+	_, err = enc.Encrypt(encrypted)
+	is.True(errors.Is(err, ErrAlreadyEncrypted))
+
+	// tampering with the ciphertext is detected
+	tampered := encrypted.Clone()
+	raw := tampered.Payload.After.(RawData)
+	raw[len(raw)-1] ^= 0xff
+	_, err = enc.Decrypt(tampered)
+	is.True(errors.Is(err, ErrDecryptionFailed))
+
+	// moving a ciphertext to another field is detected
+	moved := encrypted.Clone()
+	moved.Metadata.SetEncryptionFields([]string{".Payload.Before"})
+	moved.Payload.Before = moved.Payload.After
+	_, err = enc.Decrypt(moved)
+	is.True(errors.Is(err, ErrDecryptionFailed))
+}
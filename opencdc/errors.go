@@ -28,4 +28,51 @@ var (
 	// ErrInvalidProtoDataType is returned when trying to convert a proto data
 	// type to raw or structured data.
 	ErrInvalidProtoDataType = errors.New("invalid proto data type")
+	// ErrInvalidPath is returned when a path can't be parsed or does not
+	// point to a valid location in a record.
+	ErrInvalidPath = errors.New("invalid path")
+	// ErrFieldNotFound is returned when a path points to a field that does
+	// not exist.
+	ErrFieldNotFound = errors.New("field not found")
+	// ErrNotStructuredData is returned when structured data is required, but
+	// the record contains RawData (e.g. a path points into RawData).
+	ErrNotStructuredData = errors.New("data is not structured")
+	// ErrInvalidFieldType is returned when a value has a type that doesn't
+	// support the requested operation.
+	ErrInvalidFieldType = errors.New("invalid field type")
+	// ErrMissingValue is returned when validating a record and a required
+	// value is missing.
+	ErrMissingValue = errors.New("missing value")
+	// ErrUnexpectedValue is returned when validating a record and a value is
+	// populated that should be empty.
+	ErrUnexpectedValue = errors.New("unexpected value")
+	// ErrInvalidMetadataValue is returned when validating a record and a
+	// metadata field contains a value that can't be parsed.
+	ErrInvalidMetadataValue = errors.New("invalid metadata value")
+	// ErrInvalidRedactRule is returned when creating a Redactor with a rule
+	// that is misconfigured.
+	ErrInvalidRedactRule = errors.New("invalid redact rule")
+	// ErrEncryptionKeyNotFound is returned by a KeyProvider when a key with the
+	// requested ID does not exist.
+	ErrEncryptionKeyNotFound = errors.New("encryption key not found")
+	// ErrAlreadyEncrypted is returned when encrypting a record that already
+	// contains encrypted fields.
+	ErrAlreadyEncrypted = errors.New("record already contains encrypted fields")
+	// ErrDecryptionFailed is returned when an encrypted value can't be
+	// decrypted, e.g. because it was modified or encrypted with another key.
+	ErrDecryptionFailed = errors.New("decryption failed")
+	// ErrMemoryLimitExceeded is returned by FileReassembler when a chunk
+	// exceeds the memory limit and spilling to disk is disabled.
+	ErrMemoryLimitExceeded = errors.New("memory limit exceeded")
+	// ErrFileHashMismatch is returned by FileReassembler when the hash of a
+	// reassembled file does not match the expected hash.
+	ErrFileHashMismatch = errors.New("file hash mismatch")
+	// ErrInvalidPosition is returned when a position can't be decoded.
+	ErrInvalidPosition = errors.New("invalid position")
+	// ErrPositionsNotComparable is returned when two positions have no
+	// defined order.
+	ErrPositionsNotComparable = errors.New("positions are not comparable")
+	// ErrInvalidPartitionCount is returned by a Partitioner when the number
+	// of partitions is not positive.
+	ErrInvalidPartitionCount = errors.New("invalid partition count")
 )
@@ -0,0 +1,66 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command metadatagen generates typed opencdc.MetadataKey definitions for the
+// metadata keys defined as file options in the proto files.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// protoFiles are the proto files containing metadata key options, relative to
+// the proto directory.
+var protoFiles = []string{
+	"opencdc/v1/opencdc.proto",
+	"metadata/v1/constants.proto",
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("metadatagen: ")
+
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	var (
+		protoDir = flags.String("proto-dir", "proto", "path to the proto directory")
+		output   = flags.String("out", "metadata_keys.go", "path of the output file")
+	)
+	// flags is set up to exit on error, we can safely ignore the error
+	_ = flags.Parse(os.Args[1:])
+
+	var keys []MetadataKey
+	for _, f := range protoFiles {
+		src, err := os.ReadFile(filepath.Join(*protoDir, f))
+		if err != nil {
+			log.Fatalf("error: failed to read proto file: %v", err)
+		}
+		k, err := ParseProto(string(src))
+		if err != nil {
+			log.Fatalf("error: failed to parse %s: %v", f, err)
+		}
+		keys = append(keys, k...)
+	}
+
+	code, err := GenerateCode(keys)
+	if err != nil {
+		log.Fatalf("error: failed to generate code: %v", err)
+	}
+	err = os.WriteFile(*output, code, 0o600)
+	if err != nil {
+		log.Fatalf("error: failed to output file: %v", err)
+	}
+}
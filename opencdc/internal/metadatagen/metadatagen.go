@@ -0,0 +1,143 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MetadataKey is a metadata key parsed from a proto file.
+type MetadataKey struct {
+	// Option is the name of the proto file option (e.g. metadata_created_at).
+	Option string
+	// Name is the metadata key (e.g. opencdc.createdAt).
+	Name string
+	// Description is the comment of the option.
+	Description string
+}
+
+var (
+	optionValueRegex = regexp.MustCompile(`^option \((metadata_\w+)\) = ("(?:[^"\\]|\\.)*");$`)
+	extensionRegex   = regexp.MustCompile(`^string (metadata_\w+) = \d+;$`)
+)
+
+// ParseProto extracts metadata keys from the source of a proto file. Metadata
+// keys are string file options prefixed with "metadata_", the value of the
+// option is the metadata key and the comment of the option is used as the
+// description.
+func ParseProto(src string) ([]MetadataKey, error) {
+	values := make(map[string]string)
+	var keys []MetadataKey
+	var comment []string
+	for _, line := range strings.Split(src, "\n") {
+		line = strings.TrimSpace(line)
+		if c, ok := strings.CutPrefix(line, "//"); ok {
+			comment = append(comment, strings.TrimSpace(c))
+			continue
+		}
+		if m := optionValueRegex.FindStringSubmatch(line); m != nil {
+			v, err := strconv.Unquote(m[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of option %s: %w", m[1], err)
+			}
+			values[m[1]] = v
+		} else if m := extensionRegex.FindStringSubmatch(line); m != nil {
+			keys = append(keys, MetadataKey{
+				Option:      m[1],
+				Description: strings.Join(comment, " "),
+			})
+		}
+		comment = nil
+	}
+
+	for i, k := range keys {
+		v, ok := values[k.Option]
+		if !ok {
+			return nil, fmt.Errorf("missing value for option %s", k.Option)
+		}
+		keys[i].Name = v
+	}
+	return keys, nil
+}
+
+// goTypes defines the Go types of metadata values that are not strings.
+var goTypes = map[string]string{
+	"metadata_created_at":             "Time",
+	"metadata_read_at":                "Time",
+	"metadata_key_schema_version":     "Int",
+	"metadata_payload_schema_version": "Int",
+	"metadata_file_size":              "Int64",
+	"metadata_file_chunked":           "Bool",
+	"metadata_file_chunk_index":       "Int",
+	"metadata_file_chunk_count":       "Int",
+}
+
+// goNames defines Go names that don't follow from the option name.
+var goNames = map[string]string{
+	"metadata_version": "MetadataOpenCDCVersion",
+}
+
+// initialisms are written in upper case in Go names.
+var initialisms = map[string]bool{
+	"id":  true,
+	"dlq": true,
+}
+
+// GoName returns the Go name of the constant for the option, e.g.
+// "metadata_conduit_dlq_nack_error" becomes "MetadataConduitDLQNackError".
+func GoName(option string) string {
+	if name, ok := goNames[option]; ok {
+		return name
+	}
+	var sb strings.Builder
+	for _, part := range strings.Split(option, "_") {
+		if initialisms[part] {
+			sb.WriteString(strings.ToUpper(part))
+			continue
+		}
+		sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return sb.String()
+}
+
+// GenerateCode generates the Go source declaring and registering a typed
+// metadata key for each of the keys.
+func GenerateCode(keys []MetadataKey) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by metadatagen; DO NOT EDIT.\n\n")
+	buf.WriteString("package opencdc\n\n")
+	buf.WriteString("var (\n")
+	for _, k := range keys {
+		typ, ok := goTypes[k.Option]
+		if !ok {
+			typ = "String"
+		}
+		name := GoName(k.Option)
+		fmt.Fprintf(&buf, "\t// %sKey is a typed metadata key for %s.\n", name, name)
+		fmt.Fprintf(&buf, "\t%sKey = RegisterMetadataKey(New%sMetadataKey(\n\t\t%q,\n\t\t%q,\n\t))\n", name, typ, k.Name, k.Description)
+	}
+	buf.WriteString(")\n")
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format code: %w", err)
+	}
+	return code, nil
+}
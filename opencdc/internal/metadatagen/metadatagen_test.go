@@ -0,0 +1,78 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestParseProto(t *testing.T) {
+	is := is.New(t)
+
+	src := `syntax = "proto3";
+option (metadata_foo) = "foo.key";
+option (metadata_bar_id) = "bar.id";
+
+extend google.protobuf.FileOptions {
+  // Metadata field "foo.key" contains foo.
+  // Second line.
+  string metadata_foo = 10000;
+
+  string metadata_bar_id = 10001;
+  // not a metadata key
+  string other = 10002;
+}`
+	keys, err := ParseProto(src)
+	is.NoErr(err)
+	is.Equal(keys, []MetadataKey{
+		{Option: "metadata_foo", Name: "foo.key", Description: `Metadata field "foo.key" contains foo. Second line.`},
+		{Option: "metadata_bar_id", Name: "bar.id", Description: ""},
+	})
+
+	_, err = ParseProto(`string metadata_missing = 1;`)
+	is.True(err != nil)
+}
+
+func TestGoName(t *testing.T) {
+	is := is.New(t)
+	is.Equal(GoName("metadata_created_at"), "MetadataCreatedAt")
+	is.Equal(GoName("metadata_conduit_dlq_nack_node_id"), "MetadataConduitDLQNackNodeID")
+	is.Equal(GoName("metadata_version"), "MetadataOpenCDCVersion")
+}
+
+// TestGeneratedCodeUpToDate makes sure the generated file matches the proto
+// files.
+func TestGeneratedCodeUpToDate(t *testing.T) {
+	is := is.New(t)
+
+	var keys []MetadataKey
+	for _, f := range protoFiles {
+		src, err := os.ReadFile(filepath.Join("../../../proto", f))
+		is.NoErr(err)
+		k, err := ParseProto(string(src))
+		is.NoErr(err)
+		keys = append(keys, k...)
+	}
+	want, err := GenerateCode(keys)
+	is.NoErr(err)
+
+	got, err := os.ReadFile("../../metadata_keys.go")
+	is.NoErr(err)
+	is.Equal(string(got), string(want)) // run go generate in package opencdc
+}
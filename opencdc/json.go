@@ -15,6 +15,7 @@
 package opencdc
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
@@ -39,7 +40,78 @@ func WithJSONMarshalOptions(ctx context.Context, options *JSONMarshalOptions) co
 	return context.WithValue(ctx, jsonMarshalOptionsCtxKey{}, options)
 }
 
+// JSONNumberDecoding defines how numbers in StructuredData are decoded from
+// JSON.
+type JSONNumberDecoding int
+
+const (
+	// JSONNumberDecodingFloat64 decodes all numbers as float64. Integers that
+	// can't be represented exactly as float64 (larger than 2^53) lose
+	// precision. This is the default.
+	JSONNumberDecodingFloat64 JSONNumberDecoding = iota
+	// JSONNumberDecodingNumber decodes all numbers as json.Number, which
+	// preserves their textual representation.
+	JSONNumberDecodingNumber
+	// JSONNumberDecodingInt64 decodes integers that fit into an int64 as
+	// int64, all other numbers are decoded as float64.
+	JSONNumberDecodingInt64
+)
+
+// JSONUnmarshalOptions can customize how a record is deserialized from JSON.
+// It is the counterpart of JSONMarshalOptions and can be used with
+// JSONDeserializer and JSONLinesDecoder.
+type JSONUnmarshalOptions struct {
+	// RawDataAsString is a flag that indicates if RawData is expected to be
+	// serialized as a string without conversion (see
+	// JSONMarshalOptions.RawDataAsString). If set to false, RawData is
+	// decoded from a base64 encoded string.
+	RawDataAsString bool
+	// NumberDecoding defines how numbers in StructuredData are decoded.
+	NumberDecoding JSONNumberDecoding
+}
+
+type jsonUnmarshalOptionsCtxKey struct{}
+
+// WithJSONUnmarshalOptions attaches JSONUnmarshalOptions to a context, which
+// can be supplied to Record.UnmarshalJSONContext.
+func WithJSONUnmarshalOptions(ctx context.Context, options *JSONUnmarshalOptions) context.Context {
+	return context.WithValue(ctx, jsonUnmarshalOptionsCtxKey{}, options)
+}
+
+// UnmarshalJSON parses the JSON representation of a record using the default
+// options. Use UnmarshalJSONContext or JSONDeserializer to customize the
+// behavior (e.g. to decode numbers without losing precision).
+//
+// A key or payload that is null or missing is decoded as nil Data, which is
+// how MarshalJSON encodes nil Data, so records without a key or payload
+// round-trip unchanged.
 func (r *Record) UnmarshalJSON(b []byte) error {
+	return r.unmarshalJSON(b, nil)
+}
+
+// UnmarshalJSONContext parses the JSON representation of a record using the
+// JSONUnmarshalOptions attached to the context with WithJSONUnmarshalOptions.
+//
+// Note that the method can't be named UnmarshalJSON, as Record already
+// implements json.Unmarshaler, and go-json only supports one of the two
+// signatures per type: json.Unmarshal panics for types that only implement
+// the context-aware variant and json.UnmarshalContext panics for types that
+// only implement json.Unmarshaler. Call this method directly instead of
+// json.UnmarshalContext.
+func (r *Record) UnmarshalJSONContext(ctx context.Context, b []byte) error {
+	var options *JSONUnmarshalOptions
+	if ctx != nil {
+		//nolint:forcetypeassert // We know the type of the value.
+		if v := ctx.Value(jsonUnmarshalOptionsCtxKey{}); v != nil {
+			options = v.(*JSONUnmarshalOptions)
+		}
+	}
+	return r.unmarshalJSON(b, options)
+}
+
+// unmarshalJSON parses the JSON representation of a record using the supplied
+// options, which can be nil.
+func (r *Record) unmarshalJSON(b []byte, options *JSONUnmarshalOptions) error {
 	var raw struct {
 		Position  Position  `json:"position"`
 		Operation Operation `json:"operation"`
@@ -56,17 +128,17 @@ func (r *Record) UnmarshalJSON(b []byte) error {
 		return err //nolint:wrapcheck // no additional context to add
 	}
 
-	key, err := dataUnmarshalJSON(raw.Key)
+	key, err := dataUnmarshalJSON(raw.Key, options)
 	if err != nil {
 		return err
 	}
 
-	payloadBefore, err := dataUnmarshalJSON(raw.Payload.Before)
+	payloadBefore, err := dataUnmarshalJSON(raw.Payload.Before, options)
 	if err != nil {
 		return err
 	}
 
-	payloadAfter, err := dataUnmarshalJSON(raw.Payload.After)
+	payloadAfter, err := dataUnmarshalJSON(raw.Payload.After, options)
 	if err != nil {
 		return err
 	}
@@ -83,8 +155,24 @@ func (r *Record) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
-func dataUnmarshalJSON(b []byte) (Data, error) {
+func dataUnmarshalJSON(b []byte, options *JSONUnmarshalOptions) (Data, error) {
+	if options == nil {
+		options = &JSONUnmarshalOptions{}
+	}
+	// nil Data is marshaled as null, decode it back as nil instead of empty
+	// StructuredData, a missing field is treated the same way
+	if len(b) == 0 || bytes.Equal(b, []byte("null")) {
+		return nil, nil //nolint:nilnil // no data is a valid value
+	}
 	if b[0] == '"' {
+		if options.RawDataAsString {
+			var data string
+			err := json.Unmarshal(b, &data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal raw data: %w", err)
+			}
+			return RawData(data), nil
+		}
 		var data RawData
 		err := json.Unmarshal(b, &data)
 		if err != nil {
@@ -92,10 +180,51 @@ func dataUnmarshalJSON(b []byte) (Data, error) {
 		}
 		return data, nil
 	}
+
 	var data StructuredData
-	err := json.Unmarshal(b, &data)
+	if options.NumberDecoding == JSONNumberDecodingFloat64 {
+		err := json.Unmarshal(b, &data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal structured data: %w", err)
+		}
+		return data, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	err := dec.Decode(&data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal structured data: %w", err)
 	}
+	if options.NumberDecoding == JSONNumberDecodingInt64 {
+		for k, v := range data {
+			data[k] = numbersToInt64(v)
+		}
+	}
 	return data, nil
 }
+
+// numbersToInt64 replaces json.Number values in v with int64 if they are
+// integers that fit into int64, or float64 otherwise.
+func numbersToInt64(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64() // the decoder only produces valid numbers
+		return f
+	case map[string]any:
+		for k, vv := range v {
+			v[k] = numbersToInt64(vv)
+		}
+		return v
+	case []any:
+		for i, vv := range v {
+			v[i] = numbersToInt64(vv)
+		}
+		return v
+	default:
+		return v
+	}
+}
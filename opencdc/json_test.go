@@ -15,11 +15,11 @@
 package opencdc
 
 import (
+	"context"
 	"testing"
 
 	"github.com/goccy/go-json"
 	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/matryer/is"
 )
 
@@ -78,7 +78,7 @@ func TestRecord_JSON(t *testing.T) {
 	err = json.Unmarshal(b, &got)
 	is.NoErr(err)
 
-	diff := cmp.Diff(want, got, cmpopts.IgnoreUnexported(Record{}))
+	diff := cmp.Diff(want, got, exactRecord)
 	is.Equal(diff, "")
 }
 
@@ -144,3 +144,41 @@ func BenchmarkRecord_UnmarshalJSON(b *testing.B) {
 	}
 	_ = got
 }
+
+func TestRecord_UnmarshalJSONContext(t *testing.T) {
+	is := is.New(t)
+	b := []byte(`{"position":"cG9z","operation":"create","metadata":null,"key":"Zm9v","payload":{"before":null,"after":{"id":9007199254740993}}}`)
+
+	var r Record
+	is.NoErr(json.Unmarshal(b, &r))
+	is.Equal(r.Payload.After, StructuredData{"id": float64(9007199254740993)})
+
+	ctx := WithJSONUnmarshalOptions(context.Background(), &JSONUnmarshalOptions{
+		NumberDecoding: JSONNumberDecodingInt64,
+	})
+	is.NoErr(r.UnmarshalJSONContext(ctx, b))
+	is.Equal(r.Key, RawData("foo"))
+	is.Equal(r.Payload.After, StructuredData{"id": int64(9007199254740993)})
+
+	ctx = WithJSONUnmarshalOptions(context.Background(), &JSONUnmarshalOptions{RawDataAsString: true})
+	is.NoErr(r.UnmarshalJSONContext(ctx, b))
+	is.Equal(r.Key, RawData("Zm9v"))
+}
+
+func TestRecord_UnmarshalJSON_NilData(t *testing.T) {
+	is := is.New(t)
+
+	var r Record
+	is.NoErr(json.Unmarshal([]byte(`{"position":"cG9z","operation":"delete","key":null,"payload":{"before":null}}`), &r))
+	// compare interfaces directly, is.Equal treats nil StructuredData as nil
+	is.True(r.Key == nil)
+	is.True(r.Payload.Before == nil)
+	is.True(r.Payload.After == nil) // missing field
+
+	// nil data round-trips
+	want := Record{Position: Position("pos"), Operation: OperationDelete}
+	var got Record
+	is.NoErr(json.Unmarshal(want.Bytes(), &got))
+	is.True(got.Key == nil)
+	is.True(got.Payload == Change{})
+}
@@ -0,0 +1,110 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// JSONLinesEncoder writes records to an output stream as JSON Lines, i.e. one
+// JSON encoded record per line.
+type JSONLinesEncoder struct {
+	w          io.Writer
+	serializer JSONSerializer
+}
+
+// NewJSONLinesEncoder returns a new encoder that writes to w. The options
+// customize how records are serialized, they can be nil.
+func NewJSONLinesEncoder(w io.Writer, options *JSONMarshalOptions) *JSONLinesEncoder {
+	var serializer JSONSerializer
+	if options != nil {
+		serializer = JSONSerializer(*options)
+	}
+	return &JSONLinesEncoder{
+		w:          w,
+		serializer: serializer,
+	}
+}
+
+// Encode writes the JSON encoding of r followed by a newline character to the
+// stream.
+func (e *JSONLinesEncoder) Encode(r Record) error {
+	b, err := e.serializer.Serialize(r)
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(append(b, '\n'))
+	if err != nil {
+		return fmt.Errorf("failed to write record: %w", err)
+	}
+	return nil
+}
+
+// JSONLinesDecoder reads records from an input stream containing JSON Lines,
+// i.e. one JSON encoded record per line. Empty lines are skipped. The stream is
+// read line by line, only the current line is kept in memory.
+type JSONLinesDecoder struct {
+	r       *bufio.Reader
+	options *JSONUnmarshalOptions
+	line    int
+}
+
+// NewJSONLinesDecoder returns a new decoder that reads from r. The options
+// customize how records are deserialized, they can be nil.
+func NewJSONLinesDecoder(r io.Reader, options *JSONUnmarshalOptions) *JSONLinesDecoder {
+	return &JSONLinesDecoder{
+		r:       bufio.NewReader(r),
+		options: options,
+	}
+}
+
+// Decode reads the next record from the stream and stores it in r. At the end
+// of the stream Decode returns io.EOF. If a line can't be parsed, the returned
+// error contains the line number.
+func (d *JSONLinesDecoder) Decode(r *Record) error {
+	for {
+		line, err := d.r.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read line %d: %w", d.line+1, err)
+		}
+		if len(line) == 0 && err != nil {
+			return io.EOF
+		}
+		d.line++
+
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			if err != nil {
+				return io.EOF
+			}
+			continue // skip empty lines
+		}
+
+		parseErr := r.unmarshalJSON(line, d.options)
+		if parseErr != nil {
+			return fmt.Errorf("failed to decode record on line %d: %w", d.line, parseErr)
+		}
+		return nil
+	}
+}
+
+// Line returns the number of the line that was last read by Decode.
+func (d *JSONLinesDecoder) Line() int {
+	return d.line
+}
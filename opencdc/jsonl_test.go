@@ -0,0 +1,132 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/matryer/is"
+)
+
+func TestJSONLines_EncodeDecode(t *testing.T) {
+	records := []Record{
+		{
+			Position:  Position("pos-1"),
+			Operation: OperationCreate,
+			Metadata:  Metadata{"foo": "bar"},
+			Key:       RawData("key-1"),
+			Payload:   Change{After: StructuredData{"id": 1.0}},
+		},
+		{
+			Position:  Position("pos-2"),
+			Operation: OperationDelete,
+			Key:       StructuredData{"id": 2.0},
+			Payload:   Change{Before: RawData("multi\nline")},
+		},
+	}
+
+	testCases := []struct {
+		name             string
+		options          *JSONMarshalOptions
+		unmarshalOptions *JSONUnmarshalOptions
+		wantLine         string
+	}{{
+		name:             "default",
+		options:          nil,
+		unmarshalOptions: nil,
+		wantLine:         `{"position":"cG9zLTE=","operation":"create","metadata":{"foo":"bar"},"key":"a2V5LTE=","payload":{"before":null,"after":{"id":1}}}`,
+	}, {
+		name:             "raw data as string",
+		options:          &JSONMarshalOptions{RawDataAsString: true},
+		unmarshalOptions: &JSONUnmarshalOptions{RawDataAsString: true},
+		wantLine:         `{"position":"cG9zLTE=","operation":"create","metadata":{"foo":"bar"},"key":"key-1","payload":{"before":null,"after":{"id":1}}}`,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+
+			var buf bytes.Buffer
+			enc := NewJSONLinesEncoder(&buf, tc.options)
+			for _, r := range records {
+				is.NoErr(enc.Encode(r))
+			}
+
+			lines := strings.Split(buf.String(), "\n")
+			is.Equal(len(lines), len(records)+1) // trailing newline
+			is.Equal(lines[0], tc.wantLine)
+
+			dec := NewJSONLinesDecoder(&buf, tc.unmarshalOptions)
+			for _, want := range records {
+				var got Record
+				is.NoErr(dec.Decode(&got))
+				is.Equal(cmp.Diff(want, got, exactRecord), "")
+			}
+			var r Record
+			is.Equal(dec.Decode(&r), io.EOF)
+			is.Equal(dec.Line(), 2)
+		})
+	}
+}
+
+func TestJSONLinesDecoder_SkipsEmptyLines(t *testing.T) {
+	is := is.New(t)
+
+	input := "\n" + `{"position":"MQ==","operation":"create"}` + "\n\n  \n" + `{"position":"Mg==","operation":"update"}`
+	dec := NewJSONLinesDecoder(strings.NewReader(input), nil)
+
+	var r Record
+	is.NoErr(dec.Decode(&r))
+	is.Equal(r.Position, Position("1"))
+	is.Equal(dec.Line(), 2)
+
+	is.NoErr(dec.Decode(&r))
+	is.Equal(r.Position, Position("2"))
+	is.Equal(r.Operation, OperationUpdate)
+	is.Equal(dec.Line(), 5)
+
+	is.Equal(dec.Decode(&r), io.EOF)
+}
+
+func TestJSONLinesDecoder_Error(t *testing.T) {
+	is := is.New(t)
+
+	input := `{"operation":"create"}` + "\n" + `{"operation":"create"` + "\n"
+	dec := NewJSONLinesDecoder(strings.NewReader(input), nil)
+
+	var r Record
+	is.NoErr(dec.Decode(&r))
+	err := dec.Decode(&r)
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "line 2"))
+}
+
+func TestJSONLinesDecoder_NumberDecoding(t *testing.T) {
+	is := is.New(t)
+
+	input := `{"key":{"id":9007199254740993},"payload":{"after":{"id":9007199254740993,"price":1.5}}}`
+	dec := NewJSONLinesDecoder(strings.NewReader(input), &JSONUnmarshalOptions{
+		NumberDecoding: JSONNumberDecodingInt64,
+	})
+
+	var r Record
+	is.NoErr(dec.Decode(&r))
+	is.Equal(r.Key, StructuredData{"id": int64(9007199254740993)})
+	is.Equal(r.Payload.After, StructuredData{"id": int64(9007199254740993), "price": 1.5})
+}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"strconv"
 	"time"
+
+	"github.com/goccy/go-json"
 )
 
 const (
@@ -101,6 +103,40 @@ const (
 	// MetadataConduitDLQNackNodeID is a Record.Metadata key for the ID of the
 	// internal node that nacked the record.
 	MetadataConduitDLQNackNodeID = "conduit.dlq.nack.node.id"
+
+	// MetadataEncryptionKeyID is a Record.Metadata key for the ID of the key
+	// that was used to encrypt fields in the record (see FieldEncryptor). It
+	// is not part of the OpenCDC specification, so it doesn't use the
+	// reserved "opencdc." prefix.
+	MetadataEncryptionKeyID = "encryption.key.id"
+	// MetadataEncryptionFields is a Record.Metadata key for the paths of the
+	// encrypted fields, encoded as a JSON array of strings (see
+	// FieldEncryptor).
+	MetadataEncryptionFields = "encryption.fields"
+)
+
+var (
+	// MetadataEncryptionKeyIDKey is a typed metadata key for
+	// MetadataEncryptionKeyID.
+	MetadataEncryptionKeyIDKey = RegisterMetadataKey(NewStringMetadataKey(
+		MetadataEncryptionKeyID,
+		"Metadata field \"encryption.key.id\" contains the ID of the key that was used to encrypt fields in the record.",
+	))
+	// MetadataEncryptionFieldsKey is a typed metadata key for
+	// MetadataEncryptionFields.
+	MetadataEncryptionFieldsKey = RegisterMetadataKey(MetadataKey[[]string]{
+		Name:        MetadataEncryptionFields,
+		Description: "Metadata field \"encryption.fields\" contains the paths of the encrypted fields in the record, encoded as a JSON array of strings.",
+		Parse: func(s string) ([]string, error) {
+			var fields []string
+			err := json.Unmarshal([]byte(s), &fields)
+			return fields, err //nolint:wrapcheck // wrapped in MetadataKey.Get
+		},
+		Format: func(fields []string) string {
+			b, _ := json.Marshal(fields) // marshaling a string slice can't fail
+			return string(b)
+		},
+	})
 )
 
 type Metadata map[string]string
@@ -435,6 +471,31 @@ func (m Metadata) SetFileChunkCount(i int) {
 	m[MetadataFileChunkCount] = strconv.Itoa(i)
 }
 
+// GetEncryptionKeyID gets the metadata value for key MetadataEncryptionKeyID.
+// If the value does not exist or is empty the function returns
+// ErrMetadataFieldNotFound.
+func (m Metadata) GetEncryptionKeyID() (string, error) {
+	return MetadataEncryptionKeyIDKey.Get(m)
+}
+
+// SetEncryptionKeyID sets the metadata value for key MetadataEncryptionKeyID.
+func (m Metadata) SetEncryptionKeyID(id string) {
+	MetadataEncryptionKeyIDKey.Set(m, id)
+}
+
+// GetEncryptionFields gets the metadata value for key
+// MetadataEncryptionFields. If the value does not exist or is empty the
+// function returns ErrMetadataFieldNotFound.
+func (m Metadata) GetEncryptionFields() ([]string, error) {
+	return MetadataEncryptionFieldsKey.Get(m)
+}
+
+// SetEncryptionFields sets the metadata value for key
+// MetadataEncryptionFields.
+func (m Metadata) SetEncryptionFields(fields []string) {
+	MetadataEncryptionFieldsKey.Set(m, fields)
+}
+
 // getValue returns the value for a specific key. If the value does not exist or
 // is empty the function returns ErrMetadataFieldNotFound.
 func (m Metadata) getValue(key string) (string, error) {
@@ -0,0 +1,181 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:generate go run ./internal/metadatagen -proto-dir ../proto -out metadata_keys.go
+
+package opencdc
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MetadataKey is a typed Record.Metadata key. It defines how the metadata
+// value is parsed from and formatted to a string. Connectors can declare their
+// own keys and register them using RegisterMetadataKey.
+type MetadataKey[T any] struct {
+	// Name is the key used in Record.Metadata.
+	Name string
+	// Description describes the value stored under the key.
+	Description string
+	// Parse parses the metadata value.
+	Parse func(string) (T, error)
+	// Format formats a value to be stored in metadata.
+	Format func(T) string
+}
+
+// Get gets the value for the key from the metadata. If the value does not
+// exist or is empty the function returns ErrMetadataFieldNotFound.
+func (k MetadataKey[T]) Get(m Metadata) (T, error) {
+	str, err := m.getValue(k.Name)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	v, err := k.Parse(str)
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("invalid value %q for metadata field %q: %w", str, k.Name, err)
+	}
+	return v, nil
+}
+
+// Set formats the value and stores it in the metadata.
+func (k MetadataKey[T]) Set(m Metadata, v T) {
+	m[k.Name] = k.Format(v)
+}
+
+// Delete removes the key from the metadata.
+func (k MetadataKey[T]) Delete(m Metadata) {
+	delete(m, k.Name)
+}
+
+// Info returns the description of the key.
+func (k MetadataKey[T]) Info() MetadataKeyInfo {
+	var zero T
+	return MetadataKeyInfo{
+		Name:        k.Name,
+		Description: k.Description,
+		Type:        fmt.Sprintf("%T", zero),
+	}
+}
+
+// NewStringMetadataKey returns a metadata key storing a string.
+func NewStringMetadataKey(name, description string) MetadataKey[string] {
+	return MetadataKey[string]{
+		Name:        name,
+		Description: description,
+		Parse:       func(s string) (string, error) { return s, nil },
+		Format:      func(s string) string { return s },
+	}
+}
+
+// NewIntMetadataKey returns a metadata key storing an int.
+func NewIntMetadataKey(name, description string) MetadataKey[int] {
+	return MetadataKey[int]{
+		Name:        name,
+		Description: description,
+		Parse:       strconv.Atoi,
+		Format:      strconv.Itoa,
+	}
+}
+
+// NewInt64MetadataKey returns a metadata key storing an int64.
+func NewInt64MetadataKey(name, description string) MetadataKey[int64] {
+	return MetadataKey[int64]{
+		Name:        name,
+		Description: description,
+		Parse:       func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) },
+		Format:      func(i int64) string { return strconv.FormatInt(i, 10) },
+	}
+}
+
+// NewBoolMetadataKey returns a metadata key storing a bool.
+func NewBoolMetadataKey(name, description string) MetadataKey[bool] {
+	return MetadataKey[bool]{
+		Name:        name,
+		Description: description,
+		Parse:       strconv.ParseBool,
+		Format:      strconv.FormatBool,
+	}
+}
+
+// NewTimeMetadataKey returns a metadata key storing a time as a unix
+// timestamp in nanoseconds.
+func NewTimeMetadataKey(name, description string) MetadataKey[time.Time] {
+	return MetadataKey[time.Time]{
+		Name:        name,
+		Description: description,
+		Parse: func(s string) (time.Time, error) {
+			nanos, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return time.Time{}, err //nolint:wrapcheck // wrapped in MetadataKey.Get
+			}
+			return time.Unix(0, nanos), nil
+		},
+		Format: func(t time.Time) string { return strconv.FormatInt(t.UnixNano(), 10) },
+	}
+}
+
+// MetadataKeyInfo describes a registered metadata key.
+type MetadataKeyInfo struct {
+	// Name is the key used in Record.Metadata.
+	Name string
+	// Description describes the value stored under the key.
+	Description string
+	// Type is the Go type of the value.
+	Type string
+}
+
+var metadataKeyRegistry = struct {
+	sync.RWMutex
+	keys map[string]MetadataKeyInfo
+}{keys: make(map[string]MetadataKeyInfo)}
+
+// RegisterMetadataKey registers the key, so it's listed by MetadataKeys, and
+// returns it. It is meant to be used when declaring a key in a package level
+// variable. It panics if a key with the same name is already registered.
+func RegisterMetadataKey[T any](k MetadataKey[T]) MetadataKey[T] {
+	metadataKeyRegistry.Lock()
+	defer metadataKeyRegistry.Unlock()
+	if _, ok := metadataKeyRegistry.keys[k.Name]; ok {
+		panic(fmt.Errorf("metadata key %q is already registered", k.Name))
+	}
+	metadataKeyRegistry.keys[k.Name] = k.Info()
+	return k
+}
+
+// MetadataKeys returns all registered metadata keys sorted by name.
+func MetadataKeys() []MetadataKeyInfo {
+	metadataKeyRegistry.RLock()
+	defer metadataKeyRegistry.RUnlock()
+	keys := make([]MetadataKeyInfo, 0, len(metadataKeyRegistry.keys))
+	for _, info := range metadataKeyRegistry.keys {
+		keys = append(keys, info)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+	return keys
+}
+
+// LookupMetadataKey returns the registered metadata key with the supplied
+// name.
+func LookupMetadataKey(name string) (MetadataKeyInfo, bool) {
+	metadataKeyRegistry.RLock()
+	defer metadataKeyRegistry.RUnlock()
+	info, ok := metadataKeyRegistry.keys[name]
+	return info, ok
+}
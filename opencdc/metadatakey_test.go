@@ -0,0 +1,148 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestMetadataKey_GeneratedNames(t *testing.T) {
+	is := is.New(t)
+	want := map[string]string{
+		MetadataOpenCDCVersion:                  MetadataOpenCDCVersionKey.Name,
+		MetadataCreatedAt:                       MetadataCreatedAtKey.Name,
+		MetadataReadAt:                          MetadataReadAtKey.Name,
+		MetadataCollection:                      MetadataCollectionKey.Name,
+		MetadataKeySchemaSubject:                MetadataKeySchemaSubjectKey.Name,
+		MetadataKeySchemaVersion:                MetadataKeySchemaVersionKey.Name,
+		MetadataPayloadSchemaSubject:            MetadataPayloadSchemaSubjectKey.Name,
+		MetadataPayloadSchemaVersion:            MetadataPayloadSchemaVersionKey.Name,
+		MetadataFileName:                        MetadataFileNameKey.Name,
+		MetadataFileSize:                        MetadataFileSizeKey.Name,
+		MetadataFileHash:                        MetadataFileHashKey.Name,
+		MetadataFileChunked:                     MetadataFileChunkedKey.Name,
+		MetadataFileChunkIndex:                  MetadataFileChunkIndexKey.Name,
+		MetadataFileChunkCount:                  MetadataFileChunkCountKey.Name,
+		MetadataConduitSourcePluginName:         MetadataConduitSourcePluginNameKey.Name,
+		MetadataConduitSourcePluginVersion:      MetadataConduitSourcePluginVersionKey.Name,
+		MetadataConduitDestinationPluginName:    MetadataConduitDestinationPluginNameKey.Name,
+		MetadataConduitDestinationPluginVersion: MetadataConduitDestinationPluginVersionKey.Name,
+		MetadataConduitSourceConnectorID:        MetadataConduitSourceConnectorIDKey.Name,
+		MetadataConduitDLQNackError:             MetadataConduitDLQNackErrorKey.Name,
+		MetadataConduitDLQNackNodeID:            MetadataConduitDLQNackNodeIDKey.Name,
+	}
+	for constant, keyName := range want {
+		is.Equal(constant, keyName)
+		_, ok := LookupMetadataKey(constant)
+		is.True(ok)
+	}
+}
+
+func TestMetadataKey_GetSetDelete(t *testing.T) {
+	is := is.New(t)
+	m := Metadata{}
+
+	_, err := MetadataCreatedAtKey.Get(m)
+	is.True(errors.Is(err, ErrMetadataFieldNotFound))
+
+	now := time.Now()
+	MetadataCreatedAtKey.Set(m, now)
+	is.Equal(m[MetadataCreatedAt], strconv.FormatInt(now.UnixNano(), 10))
+
+	// compatible with the hand-written accessors
+	got, err := m.GetCreatedAt()
+	is.NoErr(err)
+	is.True(got.Equal(now))
+	got, err = MetadataCreatedAtKey.Get(m)
+	is.NoErr(err)
+	is.True(got.Equal(now))
+
+	MetadataCreatedAtKey.Delete(m)
+	is.Equal(len(m), 0)
+
+	m[MetadataFileSize] = "foo"
+	_, err = MetadataFileSizeKey.Get(m)
+	is.True(errors.Is(err, strconv.ErrSyntax))
+}
+
+func TestRegisterMetadataKey(t *testing.T) {
+	is := is.New(t)
+
+	type region string
+	key := RegisterMetadataKey(MetadataKey[region]{
+		Name:        "test.region",
+		Description: "Region of the record.",
+		Parse:       func(s string) (region, error) { return region(s), nil },
+		Format:      func(r region) string { return string(r) },
+	})
+
+	m := Metadata{}
+	key.Set(m, "eu")
+	got, err := key.Get(m)
+	is.NoErr(err)
+	is.Equal(got, region("eu"))
+
+	info, ok := LookupMetadataKey("test.region")
+	is.True(ok)
+	is.Equal(info, MetadataKeyInfo{Name: "test.region", Description: "Region of the record.", Type: "opencdc.region"})
+
+	keys := MetadataKeys()
+	is.True(len(keys) > 1)
+	for i := 1; i < len(keys); i++ {
+		is.True(keys[i-1].Name < keys[i].Name)
+	}
+
+	defer func() {
+		is.True(recover() != nil) // registering the same key twice panics
+	}()
+	RegisterMetadataKey(key)
+}
+
+func TestMetadataKey_Encryption(t *testing.T) {
+	is := is.New(t)
+	m := Metadata{}
+
+	_, err := m.GetEncryptionKeyID()
+	is.True(errors.Is(err, ErrMetadataFieldNotFound))
+	_, err = m.GetEncryptionFields()
+	is.True(errors.Is(err, ErrMetadataFieldNotFound))
+
+	m.SetEncryptionKeyID("key-1")
+	m.SetEncryptionFields([]string{".Key", ".Payload.After.ssn"})
+	is.Equal(m, Metadata{
+		MetadataEncryptionKeyID:  "key-1",
+		MetadataEncryptionFields: `[".Key",".Payload.After.ssn"]`,
+	})
+
+	id, err := MetadataEncryptionKeyIDKey.Get(m)
+	is.NoErr(err)
+	is.Equal(id, "key-1")
+	fields, err := MetadataEncryptionFieldsKey.Get(m)
+	is.NoErr(err)
+	is.Equal(fields, []string{".Key", ".Payload.After.ssn"})
+
+	// the keys are registered, but don't use the reserved OpenCDC prefix
+	for _, name := range []string{MetadataEncryptionKeyID, MetadataEncryptionFields} {
+		_, ok := LookupMetadataKey(name)
+		is.True(ok)
+		is.True(!strings.HasPrefix(name, "opencdc."))
+	}
+}
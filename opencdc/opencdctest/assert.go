@@ -0,0 +1,189 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package opencdctest contains utilities for testing code that produces or
+// consumes opencdc records: a fluent RecordBuilder, cmp options that compare
+// records semantically and assertions that report differences as field paths.
+package opencdctest
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/google/go-cmp/cmp"
+)
+
+// AssertRecordEqual fails the test if the records are not equal according to
+// CmpOptions and the supplied options. The differences are reported as paths
+// of the changed fields, e.g.:
+//
+//	records are not equal (-want +got):
+//	.Operation: -create +update
+//	.Payload.After.name: -"foo" +"bar"
+func AssertRecordEqual(tb testing.TB, want, got opencdc.Record, opts ...cmp.Option) {
+	tb.Helper()
+	if diff := RecordDiff(want, got, opts...); diff != "" {
+		tb.Errorf("records are not equal (-want +got):\n%s", diff)
+	}
+}
+
+// AssertRecordsEqual fails the test if the slices of records are not equal
+// according to CmpOptions and the supplied options. Differences are reported
+// like in AssertRecordEqual, prefixed with the index of the record.
+func AssertRecordsEqual(tb testing.TB, want, got []opencdc.Record, opts ...cmp.Option) {
+	tb.Helper()
+	var sb strings.Builder
+	if len(want) != len(got) {
+		fmt.Fprintf(&sb, "length: -%d +%d\n", len(want), len(got))
+	}
+	for i := 0; i < max(len(want), len(got)); i++ {
+		switch {
+		case i >= len(got):
+			fmt.Fprintf(&sb, "[%d]: missing record at position %s\n", i, want[i].Position)
+		case i >= len(want):
+			fmt.Fprintf(&sb, "[%d]: unexpected record at position %s\n", i, got[i].Position)
+		default:
+			diff := RecordDiff(want[i], got[i], opts...)
+			for _, line := range strings.SplitAfter(diff, "\n") {
+				if line != "" {
+					fmt.Fprintf(&sb, "[%d]%s", i, line)
+				}
+			}
+		}
+	}
+	if sb.Len() > 0 {
+		tb.Errorf("records are not equal (-want +got):\n%s", sb.String())
+	}
+}
+
+// RecordDiff returns a readable description of the differences between two
+// records, one line per changed field, or an empty string if the records are
+// equal according to CmpOptions and the supplied options.
+func RecordDiff(want, got opencdc.Record, opts ...cmp.Option) string {
+	options := append(CmpOptions(), opts...)
+	if cmp.Equal(want, got, options) {
+		return ""
+	}
+
+	var sb strings.Builder
+	if !bytes.Equal(want.Position, got.Position) {
+		fmt.Fprintf(&sb, ".Position: -%q +%q\n", want.Position, got.Position)
+	}
+	if want.Operation != got.Operation {
+		fmt.Fprintf(&sb, ".Operation: -%s +%s\n", want.Operation, got.Operation)
+	}
+	// Parts and fields that are equal according to the options are skipped,
+	// so that e.g. ignored metadata fields are not reported.
+	ignored := func(path string, d opencdc.FieldDiff) bool {
+		return diffIgnored(want, path, d, options)
+	}
+	if !cmp.Equal(want.Metadata, got.Metadata, options) {
+		writeFieldDiffs(&sb, ".Metadata", metadataData(want.Metadata), metadataData(got.Metadata), ignored)
+	}
+	if !cmp.Equal(want.Key, got.Key, options) {
+		writeDataDiff(&sb, ".Key", want.Key, got.Key, ignored)
+	}
+	if !cmp.Equal(want.Payload.Before, got.Payload.Before, options) {
+		writeDataDiff(&sb, ".Payload.Before", want.Payload.Before, got.Payload.Before, ignored)
+	}
+	if !cmp.Equal(want.Payload.After, got.Payload.After, options) {
+		writeDataDiff(&sb, ".Payload.After", want.Payload.After, got.Payload.After, ignored)
+	}
+
+	if sb.Len() == 0 {
+		// The records differ because of one of the supplied options, fall
+		// back to the diff produced by cmp.
+		return cmp.Diff(want, got, options)
+	}
+	return sb.String()
+}
+
+// diffIgnored reports whether the change d of the field at path (relative to
+// the record field at root) is invisible when comparing records with the
+// options, i.e. whether applying only this change to want results in a record
+// that is equal to want.
+func diffIgnored(want opencdc.Record, root string, d opencdc.FieldDiff, options cmp.Options) bool {
+	p, err := opencdc.ParsePath(root + d.Path)
+	if err != nil {
+		return false
+	}
+	changed := want.DeepClone()
+	if d.Type == opencdc.DiffRemoved {
+		err = p.Delete(&changed)
+	} else {
+		err = p.Set(&changed, d.New)
+	}
+	return err == nil && cmp.Equal(want, changed, options)
+}
+
+func writeDataDiff(sb *strings.Builder, path string, want, got opencdc.Data, ignored func(string, opencdc.FieldDiff) bool) {
+	wantSD, wantOK := want.(opencdc.StructuredData)
+	gotSD, gotOK := got.(opencdc.StructuredData)
+	if wantOK && gotOK {
+		writeFieldDiffs(sb, path, wantSD, gotSD, ignored)
+		return
+	}
+	fmt.Fprintf(sb, "%s: -%s +%s\n", path, formatData(want), formatData(got))
+}
+
+func writeFieldDiffs(sb *strings.Builder, path string, want, got opencdc.StructuredData, ignored func(string, opencdc.FieldDiff) bool) {
+	for _, d := range opencdc.Diff(want, got) {
+		if ignored(path, d) {
+			continue
+		}
+		switch d.Type {
+		case opencdc.DiffAdded:
+			fmt.Fprintf(sb, "%s%s: +%s\n", path, d.Path, formatValue(d.New))
+		case opencdc.DiffRemoved:
+			fmt.Fprintf(sb, "%s%s: -%s\n", path, d.Path, formatValue(d.Old))
+		case opencdc.DiffModified:
+			fmt.Fprintf(sb, "%s%s: -%s +%s\n", path, d.Path, formatValue(d.Old), formatValue(d.New))
+		}
+	}
+}
+
+func metadataData(m opencdc.Metadata) opencdc.StructuredData {
+	sd := make(opencdc.StructuredData, len(m))
+	for k, v := range m {
+		sd[k] = v
+	}
+	return sd
+}
+
+func formatData(d opencdc.Data) string {
+	switch d := d.(type) {
+	case nil:
+		return "nil"
+	case opencdc.RawData:
+		return fmt.Sprintf("RawData(%q)", []byte(d))
+	case opencdc.StructuredData:
+		return "StructuredData" + string(d.Bytes())
+	default:
+		return fmt.Sprintf("%#v", d)
+	}
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case string, []byte:
+		return fmt.Sprintf("%q", v)
+	case nil:
+		return "nil"
+	default:
+		return fmt.Sprintf("%v (%T)", v, v)
+	}
+}
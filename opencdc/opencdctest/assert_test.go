@@ -0,0 +1,177 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdctest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/matryer/is"
+)
+
+// recorder records errors reported by assertions.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestCmpOptions(t *testing.T) {
+	is := is.New(t)
+
+	want := NewRecord().StructuredAfter("id", 1, "score", float32(1.5), "nested", map[string]any{"n": int64(2)}).Build()
+	got := NewRecord().StructuredAfter("id", 1.0, "score", 1.5, "nested", map[string]any{"n": 2}).Build()
+	got.Metadata = nil
+	got.SetSerializer(opencdc.JSONSerializer{})
+
+	is.True(cmp.Equal(want, got, CmpOptions()))
+
+	got.Payload.After.(opencdc.StructuredData)["id"] = 2
+	is.True(!cmp.Equal(want, got, CmpOptions()))
+	got.Payload.After.(opencdc.StructuredData)["id"] = "1"
+	is.True(!cmp.Equal(want, got, CmpOptions()))
+
+	// named numeric types are not converted
+	is.True(!cmp.Equal(NewRecord().Create().Build(), NewRecord().Update().Build(), CmpOptions()))
+}
+
+func TestCmpOptions_NestedMaps(t *testing.T) {
+	is := is.New(t)
+
+	// Record.Clone converts nested maps to StructuredData
+	built := NewRecord().StructuredAfter("a", map[string]any{"b": 1}).Build().Clone()
+	literal := opencdc.Record{
+		Operation: opencdc.OperationCreate,
+		Payload: opencdc.Change{
+			After: opencdc.StructuredData{"a": map[string]any{"b": 1}},
+		},
+	}
+	is.True(literal.Equal(built))
+	is.True(cmp.Equal(literal, built, CmpOptions()))
+	is.Equal(RecordDiff(literal, built), "")
+
+	built.Payload.After.(opencdc.StructuredData)["a"].(opencdc.StructuredData)["b"] = 2
+	is.Equal(RecordDiff(literal, built), ".Payload.After.a.b: -1 (int) +2 (int)\n")
+}
+
+func TestIgnoreMetadata(t *testing.T) {
+	is := is.New(t)
+
+	want := NewRecord().Meta(opencdc.MetadataReadAt, "1").Meta("foo", "bar").Build()
+	got := NewRecord().Meta(opencdc.MetadataReadAt, "2").Meta("foo", "bar").Build()
+	is.True(!cmp.Equal(want, got, CmpOptions()))
+	is.True(cmp.Equal(want, got, CmpOptions(), IgnoreMetadata(opencdc.MetadataReadAt)))
+
+	// only metadata is ignored
+	want.Payload.After = opencdc.StructuredData{opencdc.MetadataReadAt: 1}
+	is.True(!cmp.Equal(want, got, CmpOptions(), IgnoreMetadata(opencdc.MetadataReadAt)))
+}
+
+func TestRecordDiff(t *testing.T) {
+	is := is.New(t)
+
+	want := NewRecord().
+		Position("1").
+		Meta("foo", "bar").
+		StructuredKey("id", 1).
+		StructuredAfter("name", "foo", "tags", []any{"a", "b"}, "age", 30).
+		Build()
+	got := NewRecord().
+		Position("2").
+		Update().
+		Meta("baz", "qux").
+		RawKey("1").
+		RawBefore("old").
+		StructuredAfter("name", "bar", "tags", []any{"a"}, "age", 30.0).
+		Build()
+
+	is.Equal(RecordDiff(want, want), "")
+	is.Equal(RecordDiff(want, got), `.Position: -"1" +"2"
+.Operation: -create +update
+.Metadata.baz: +"qux"
+.Metadata.foo: -"bar"
+.Key: -StructuredData{"id":1} +RawData("1")
+.Payload.Before: -nil +RawData("old")
+.Payload.After.name: -"foo" +"bar"
+.Payload.After.tags[1]: -"b"
+`)
+}
+
+func TestRecordDiff_Options(t *testing.T) {
+	is := is.New(t)
+
+	want := NewRecord().
+		Meta(opencdc.MetadataReadAt, "1").
+		Meta("foo", "bar").
+		StructuredAfter("name", "foo", "updatedAt", 1).
+		Build()
+	got := NewRecord().
+		Meta(opencdc.MetadataReadAt, "2").
+		Meta("foo", "baz").
+		StructuredAfter("name", "bar", "updatedAt", 2).
+		Build()
+
+	// fields ignored by the options are not reported
+	is.Equal(RecordDiff(want, got,
+		IgnoreMetadata(opencdc.MetadataReadAt),
+		cmpopts.IgnoreMapEntries(func(k string, _ any) bool { return k == "updatedAt" }),
+	), `.Metadata.foo: -"bar" +"baz"
+.Payload.After.name: -"foo" +"bar"
+`)
+}
+
+func TestAssertRecordEqual(t *testing.T) {
+	is := is.New(t)
+
+	var r recorder
+	want := NewRecord().StructuredAfter("id", 1).Build()
+	AssertRecordEqual(&r, want, NewRecord().StructuredAfter("id", 1.0).Build())
+	is.Equal(len(r.errors), 0)
+
+	AssertRecordEqual(&r, want, NewRecord().StructuredAfter("id", 2).Build())
+	is.Equal(r.errors, []string{"records are not equal (-want +got):\n.Payload.After.id: -1 (int) +2 (int)\n"})
+}
+
+func TestAssertRecordsEqual(t *testing.T) {
+	is := is.New(t)
+
+	var r recorder
+	want := []opencdc.Record{
+		NewRecord().Position("1").Build(),
+		NewRecord().Position("2").Build(),
+	}
+	AssertRecordsEqual(&r, want, []opencdc.Record{
+		NewRecord().Position("1").Build(),
+		NewRecord().Position("2").Build(),
+	})
+	is.Equal(len(r.errors), 0)
+
+	AssertRecordsEqual(&r, want, []opencdc.Record{
+		NewRecord().Position("1").Delete().Build(),
+	})
+	is.Equal(r.errors, []string{`records are not equal (-want +got):
+length: -2 +1
+[0].Operation: -create +delete
+[1]: missing record at position 2
+`})
+}
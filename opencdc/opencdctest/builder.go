@@ -0,0 +1,160 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdctest
+
+import (
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// RecordBuilder builds records in tests using a fluent API:
+//
+//	rec := opencdctest.NewRecord().
+//	    Position("pos-1").
+//	    Update().
+//	    Meta(opencdc.MetadataCollection, "users").
+//	    StructuredKey("id", 1).
+//	    StructuredBefore("id", 1, "name", "foo").
+//	    StructuredAfter("id", 1, "name", "bar").
+//	    Build()
+//
+// The zero value is not usable, use NewRecord to create a builder.
+type RecordBuilder struct {
+	r opencdc.Record
+}
+
+// NewRecord returns a builder of a record with operation create and empty
+// metadata.
+func NewRecord() *RecordBuilder {
+	return &RecordBuilder{r: opencdc.Record{
+		Operation: opencdc.OperationCreate,
+		Metadata:  opencdc.Metadata{},
+	}}
+}
+
+// Position sets the position.
+func (b *RecordBuilder) Position(p string) *RecordBuilder {
+	b.r.Position = opencdc.Position(p)
+	return b
+}
+
+// Operation sets the operation.
+func (b *RecordBuilder) Operation(op opencdc.Operation) *RecordBuilder {
+	b.r.Operation = op
+	return b
+}
+
+// Create sets the operation to create.
+func (b *RecordBuilder) Create() *RecordBuilder { return b.Operation(opencdc.OperationCreate) }
+
+// Update sets the operation to update.
+func (b *RecordBuilder) Update() *RecordBuilder { return b.Operation(opencdc.OperationUpdate) }
+
+// Delete sets the operation to delete.
+func (b *RecordBuilder) Delete() *RecordBuilder { return b.Operation(opencdc.OperationDelete) }
+
+// Snapshot sets the operation to snapshot.
+func (b *RecordBuilder) Snapshot() *RecordBuilder { return b.Operation(opencdc.OperationSnapshot) }
+
+// Meta sets a metadata field.
+func (b *RecordBuilder) Meta(key, value string) *RecordBuilder {
+	b.r.Metadata[key] = value
+	return b
+}
+
+// Metadata sets all fields of the metadata.
+func (b *RecordBuilder) Metadata(m opencdc.Metadata) *RecordBuilder {
+	for k, v := range m {
+		b.r.Metadata[k] = v
+	}
+	return b
+}
+
+// Key sets the key.
+func (b *RecordBuilder) Key(d opencdc.Data) *RecordBuilder {
+	b.r.Key = d
+	return b
+}
+
+// RawKey sets the key to RawData.
+func (b *RecordBuilder) RawKey(s string) *RecordBuilder {
+	return b.Key(opencdc.RawData(s))
+}
+
+// StructuredKey sets the key to StructuredData containing the supplied
+// alternating field names and values (see StructuredData).
+func (b *RecordBuilder) StructuredKey(kv ...any) *RecordBuilder {
+	return b.Key(StructuredData(kv...))
+}
+
+// Before sets the payload before the change.
+func (b *RecordBuilder) Before(d opencdc.Data) *RecordBuilder {
+	b.r.Payload.Before = d
+	return b
+}
+
+// RawBefore sets the payload before the change to RawData.
+func (b *RecordBuilder) RawBefore(s string) *RecordBuilder {
+	return b.Before(opencdc.RawData(s))
+}
+
+// StructuredBefore sets the payload before the change to StructuredData
+// containing the supplied alternating field names and values (see
+// StructuredData).
+func (b *RecordBuilder) StructuredBefore(kv ...any) *RecordBuilder {
+	return b.Before(StructuredData(kv...))
+}
+
+// After sets the payload after the change.
+func (b *RecordBuilder) After(d opencdc.Data) *RecordBuilder {
+	b.r.Payload.After = d
+	return b
+}
+
+// RawAfter sets the payload after the change to RawData.
+func (b *RecordBuilder) RawAfter(s string) *RecordBuilder {
+	return b.After(opencdc.RawData(s))
+}
+
+// StructuredAfter sets the payload after the change to StructuredData
+// containing the supplied alternating field names and values (see
+// StructuredData).
+func (b *RecordBuilder) StructuredAfter(kv ...any) *RecordBuilder {
+	return b.After(StructuredData(kv...))
+}
+
+// Build returns a deep copy of the record (see opencdc.Record.DeepClone). The
+// builder can be used further to build variations of the record, changes
+// don't affect the returned records and returned records don't share data.
+func (b *RecordBuilder) Build() opencdc.Record {
+	return b.r.DeepClone()
+}
+
+// StructuredData returns StructuredData containing the alternating field
+// names and values, e.g. StructuredData("id", 1, "name", "foo"). It panics
+// if a field name is not a string or a value is missing.
+func StructuredData(kv ...any) opencdc.StructuredData {
+	if len(kv)%2 != 0 {
+		panic("opencdctest: StructuredData needs an even number of arguments")
+	}
+	sd := make(opencdc.StructuredData, len(kv)/2)
+	for i := 0; i < len(kv); i += 2 {
+		k, ok := kv[i].(string)
+		if !ok {
+			panic("opencdctest: StructuredData field names need to be strings")
+		}
+		sd[k] = kv[i+1]
+	}
+	return sd
+}
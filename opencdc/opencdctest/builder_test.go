@@ -0,0 +1,94 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdctest
+
+import (
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/google/go-cmp/cmp"
+	"github.com/matryer/is"
+)
+
+func TestRecordBuilder(t *testing.T) {
+	is := is.New(t)
+
+	b := NewRecord().
+		Position("pos-1").
+		Update().
+		Meta(opencdc.MetadataCollection, "users").
+		StructuredKey("id", 1).
+		StructuredBefore("id", 1, "name", "foo").
+		StructuredAfter("id", 1, "name", "bar")
+	got := b.Build()
+
+	want := opencdc.Record{
+		Position:  opencdc.Position("pos-1"),
+		Operation: opencdc.OperationUpdate,
+		Metadata:  opencdc.Metadata{opencdc.MetadataCollection: "users"},
+		Key:       opencdc.StructuredData{"id": 1},
+		Payload: opencdc.Change{
+			Before: opencdc.StructuredData{"id": 1, "name": "foo"},
+			After:  opencdc.StructuredData{"id": 1, "name": "bar"},
+		},
+	}
+	is.True(cmp.Equal(want, got, CmpOptions()))
+
+	// changing the builder does not affect built records
+	b.Delete().RawKey("1").Meta("foo", "bar")
+	is.True(cmp.Equal(want, got, CmpOptions()))
+	is.Equal(b.Build().Key, opencdc.RawData("1"))
+}
+
+func TestRecordBuilder_BuildDoesNotShareData(t *testing.T) {
+	is := is.New(t)
+
+	b := NewRecord().StructuredAfter(
+		"nested", map[string]any{"a": 1},
+		"list", []any{"x", "y"},
+		"tags", []string{"t"},
+	)
+	first := b.Build()
+	second := b.Build()
+
+	// nested maps keep their type
+	after := first.Payload.After.(opencdc.StructuredData)
+	_, ok := after["nested"].(map[string]any)
+	is.True(ok)
+
+	after["nested"].(map[string]any)["a"] = 2
+	after["list"].([]any)[0] = "z"
+	after["tags"].([]string)[0] = "u"
+
+	is.Equal(second.Payload.After, opencdc.StructuredData{
+		"nested": map[string]any{"a": 1},
+		"list":   []any{"x", "y"},
+		"tags":   []string{"t"},
+	})
+	is.Equal(b.Build().Payload.After, second.Payload.After)
+}
+
+func TestStructuredData_Panics(t *testing.T) {
+	for _, kv := range [][]any{{"id"}, {1, 2}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for %v", kv)
+				}
+			}()
+			StructuredData(kv...)
+		}()
+	}
+}
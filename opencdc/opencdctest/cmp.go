@@ -0,0 +1,121 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdctest
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/google/go-cmp/cmp"
+)
+
+// CmpOptions returns options for cmp that compare records semantically:
+//   - unexported fields (e.g. the serializer) are ignored,
+//   - records are compared field by field instead of using Record.Equal, so
+//     cmp reports the differing fields and options like IgnoreMetadata can
+//     be applied,
+//   - nested StructuredData and map[string]any values are equal if they
+//     contain the same fields (Record.Clone converts nested maps to
+//     StructuredData),
+//   - numbers in StructuredData are compared by value regardless of their
+//     type (e.g. int(1) equals float64(1)),
+//   - nil and empty metadata are equal.
+func CmpOptions() cmp.Options {
+	return cmp.Options{
+		cmp.Transformer("Fields", func(r opencdc.Record) recordFields {
+			return recordFields{
+				Position:  r.Position,
+				Operation: r.Operation,
+				Metadata:  r.Metadata,
+				Key:       r.Key,
+				Payload:   r.Payload,
+			}
+		}),
+		cmp.FilterValues(
+			func(x, y any) bool { return isMap(x) && isMap(y) && reflect.TypeOf(x) != reflect.TypeOf(y) },
+			cmp.Transformer("Map", toMap),
+		),
+		cmp.FilterValues(
+			func(x, y any) bool { return isNumber(x) && isNumber(y) },
+			cmp.Comparer(numbersEqual),
+		),
+		cmp.FilterValues(
+			func(x, y opencdc.Metadata) bool { return len(x) == 0 && len(y) == 0 },
+			cmp.Comparer(func(_, _ opencdc.Metadata) bool { return true }),
+		),
+	}
+}
+
+// recordFields contains the exported fields of opencdc.Record. It is used to
+// compare records without Record.Equal.
+type recordFields struct {
+	Position  opencdc.Position
+	Operation opencdc.Operation
+	Metadata  opencdc.Metadata
+	Key       opencdc.Data
+	Payload   opencdc.Change
+}
+
+// IgnoreMetadata returns a cmp option that ignores the supplied metadata
+// keys, e.g. opencdc.MetadataReadAt, which is usually set to the current time.
+func IgnoreMetadata(keys ...string) cmp.Option {
+	return cmp.FilterPath(func(p cmp.Path) bool {
+		mi, ok := p.Last().(cmp.MapIndex)
+		if !ok || len(p) < 2 || p[len(p)-2].Type() != reflect.TypeOf(opencdc.Metadata{}) {
+			return false
+		}
+		return slices.Contains(keys, mi.Key().String())
+	}, cmp.Ignore())
+}
+
+// isMap returns true if v is StructuredData or map[string]any.
+func isMap(v any) bool {
+	switch v.(type) {
+	case opencdc.StructuredData, map[string]any:
+		return true
+	default:
+		return false
+	}
+}
+
+// toMap converts StructuredData to map[string]any.
+func toMap(v any) map[string]any {
+	if sd, ok := v.(opencdc.StructuredData); ok {
+		return sd
+	}
+	return v.(map[string]any) //nolint:forcetypeassert // checked in isMap
+}
+
+// isNumber returns true if v is a value of a built-in numeric type.
+func isNumber(v any) bool {
+	t := reflect.TypeOf(v)
+	if t == nil || t.PkgPath() != "" {
+		return false // named types (e.g. opencdc.Operation) are compared as is
+	}
+	switch t.Kind() { //nolint:exhaustive // other kinds are not numbers
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// numbersEqual compares numbers the same way as opencdc.Diff.
+func numbersEqual(x, y any) bool {
+	return len(opencdc.Diff(opencdc.StructuredData{"v": x}, opencdc.StructuredData{"v": y})) == 0
+}
@@ -0,0 +1,220 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Partitioner assigns records to partitions based on their key, so that all
+// records with the same key end up in the same partition.
+//
+// The hashed bytes of a key are the bytes of RawData or the canonical
+// encoding of StructuredData (see Record.CanonicalBytes), so structured keys
+// that are semantically equal end up in the same partition, while keys with
+// values of different kinds (e.g. a byte slice and its base64 string) are
+// hashed independently. A nil key is hashed as an empty byte slice.
+type Partitioner interface {
+	// Partition returns the partition of the record, a number in the range
+	// [0, numPartitions). It returns ErrInvalidPartitionCount if numPartitions
+	// is not positive.
+	Partition(r Record, numPartitions int) (int, error)
+}
+
+// Murmur2Partitioner is compatible with the default partitioner of the Kafka
+// Java client for records with a key. Records with a RawData key are assigned
+// the same partition as a Kafka record with the same key bytes.
+type Murmur2Partitioner struct{}
+
+// Partition returns the partition of the record.
+func (Murmur2Partitioner) Partition(r Record, numPartitions int) (int, error) {
+	if err := validatePartitionCount(numPartitions); err != nil {
+		return 0, err
+	}
+	h := murmur2(partitionKey(r.Key)) & 0x7fffffff // same as Utils.toPositive in Kafka
+	return int(h % uint32(numPartitions)), nil     //nolint:gosec // validated above
+}
+
+// FNVPartitioner assigns partitions using the 32-bit FNV-1a hash of the key.
+type FNVPartitioner struct{}
+
+// Partition returns the partition of the record.
+func (FNVPartitioner) Partition(r Record, numPartitions int) (int, error) {
+	if err := validatePartitionCount(numPartitions); err != nil {
+		return 0, err
+	}
+	h := fnv.New32a()
+	_, _ = h.Write(partitionKey(r.Key))
+	return int(h.Sum32() % uint32(numPartitions)), nil //nolint:gosec // validated above
+}
+
+// DefaultConsistentHashReplicas is the number of points each partition is
+// assigned on the ring of a ConsistentHashPartitioner if not specified
+// otherwise.
+const DefaultConsistentHashReplicas = 128
+
+// ConsistentHashPartitioner assigns partitions using a consistent hash ring.
+// Each partition is assigned multiple points on the ring and a key belongs to
+// the partition of the first point following the hash of the key. When the
+// number of partitions changes, only about 1/numPartitions of the keys move
+// to a different partition, unlike modulo based partitioners, where most keys
+// move.
+type ConsistentHashPartitioner struct {
+	replicas int
+
+	m     sync.Mutex
+	rings map[int]hashRing
+}
+
+// NewConsistentHashPartitioner returns a partitioner that assigns each
+// partition the given number of points on the ring. More points distribute
+// keys more evenly. If replicas is not positive, DefaultConsistentHashReplicas
+// is used.
+func NewConsistentHashPartitioner(replicas int) *ConsistentHashPartitioner {
+	if replicas <= 0 {
+		replicas = DefaultConsistentHashReplicas
+	}
+	return &ConsistentHashPartitioner{
+		replicas: replicas,
+		rings:    make(map[int]hashRing),
+	}
+}
+
+// Partition returns the partition of the record.
+func (p *ConsistentHashPartitioner) Partition(r Record, numPartitions int) (int, error) {
+	if err := validatePartitionCount(numPartitions); err != nil {
+		return 0, err
+	}
+	return p.ring(numPartitions).partition(fnv64a(partitionKey(r.Key))), nil
+}
+
+// ring returns the ring for the number of partitions, rings are built lazily
+// and cached.
+func (p *ConsistentHashPartitioner) ring(numPartitions int) hashRing {
+	p.m.Lock()
+	defer p.m.Unlock()
+	if ring, ok := p.rings[numPartitions]; ok {
+		return ring
+	}
+	ring := newHashRing(numPartitions, p.replicas)
+	p.rings[numPartitions] = ring
+	return ring
+}
+
+// hashRing contains points sorted by their hash.
+type hashRing []hashRingPoint
+
+type hashRingPoint struct {
+	hash      uint64
+	partition int
+}
+
+func newHashRing(numPartitions, replicas int) hashRing {
+	ring := make(hashRing, 0, numPartitions*replicas)
+	var buf []byte
+	for partition := 0; partition < numPartitions; partition++ {
+		for replica := 0; replica < replicas; replica++ {
+			buf = strconv.AppendInt(buf[:0], int64(partition), 10)
+			buf = append(buf, '-')
+			buf = strconv.AppendInt(buf, int64(replica), 10)
+			ring = append(ring, hashRingPoint{hash: fnv64a(buf), partition: partition})
+		}
+	}
+	sort.Slice(ring, func(i, j int) bool {
+		if ring[i].hash == ring[j].hash {
+			return ring[i].partition < ring[j].partition
+		}
+		return ring[i].hash < ring[j].hash
+	})
+	return ring
+}
+
+func (r hashRing) partition(hash uint64) int {
+	i := sort.Search(len(r), func(i int) bool { return r[i].hash >= hash })
+	if i == len(r) {
+		i = 0 // wrap around
+	}
+	return r[i].partition
+}
+
+func validatePartitionCount(numPartitions int) error {
+	if numPartitions <= 0 {
+		return fmt.Errorf("%d: %w", numPartitions, ErrInvalidPartitionCount)
+	}
+	return nil
+}
+
+// partitionKey returns the bytes of the key that are hashed.
+func partitionKey(key Data) []byte {
+	switch key := key.(type) {
+	case nil:
+		return nil
+	case RawData:
+		return key
+	default:
+		return canonicalData(key)
+	}
+}
+
+func fnv64a(b []byte) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(b)
+	return h.Sum64()
+}
+
+// murmur2 is the 32-bit murmur2 hash as implemented in the Kafka Java client
+// (org.apache.kafka.common.utils.Utils.murmur2).
+func murmur2(data []byte) uint32 {
+	const (
+		seed = 0x9747b28c
+		m    = 0x5bd1e995
+		r    = 24
+	)
+
+	length := len(data)
+	h := uint32(seed) ^ uint32(length) //nolint:gosec // Kafka truncates the length as well
+
+	for len(data) >= 4 {
+		k := binary.LittleEndian.Uint32(data)
+		k *= m
+		k ^= k >> r
+		k *= m
+		h *= m
+		h ^= k
+		data = data[4:]
+	}
+
+	switch len(data) {
+	case 3:
+		h ^= uint32(data[2]) << 16
+		fallthrough
+	case 2:
+		h ^= uint32(data[1]) << 8
+		fallthrough
+	case 1:
+		h ^= uint32(data[0])
+		h *= m
+	}
+
+	h ^= h >> 13
+	h *= m
+	h ^= h >> 15
+	return h
+}
@@ -0,0 +1,152 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestMurmur2(t *testing.T) {
+	// test cases taken from the Kafka Java client (UtilsTest.testMurmur2)
+	testCases := map[string]int32{
+		"21":                         -973932308,
+		"foobar":                     -790332482,
+		"a-little-bit-long-string":   -985981536,
+		"a-little-bit-longer-string": -1486304829,
+		"lkjh234lh9fiuh90y23oiuhsafujhadof229phr9h19h89h8": -58897971,
+		"abc": 479470107,
+	}
+	for in, want := range testCases {
+		t.Run(in, func(t *testing.T) {
+			is := is.New(t)
+			is.Equal(int32(murmur2([]byte(in))), want) //nolint:gosec // Kafka returns a signed int
+		})
+	}
+}
+
+func TestMurmur2Partitioner_Kafka(t *testing.T) {
+	is := is.New(t)
+	// Kafka assigns partition toPositive(murmur2(key)) % numPartitions
+	testCases := map[string]int{
+		"21":     0, // toPositive(-973932308) = 1173551340
+		"foobar": 6, // toPositive(-790332482) = 1357151166
+	}
+	for key, want := range testCases {
+		got, err := Murmur2Partitioner{}.Partition(Record{Key: RawData(key)}, 10)
+		is.NoErr(err)
+		is.Equal(got, want)
+	}
+}
+
+func TestPartitioner(t *testing.T) {
+	partitioners := map[string]Partitioner{
+		"murmur2":    Murmur2Partitioner{},
+		"fnv":        FNVPartitioner{},
+		"consistent": NewConsistentHashPartitioner(0),
+	}
+	for name, p := range partitioners {
+		t.Run(name, func(t *testing.T) {
+			is := is.New(t)
+
+			_, err := p.Partition(Record{}, 0)
+			is.True(errors.Is(err, ErrInvalidPartitionCount))
+
+			// semantically equal structured keys end up in the same partition
+			p1, err := p.Partition(Record{Key: StructuredData{"id": 1, "name": "foo"}}, 100)
+			is.NoErr(err)
+			p2, err := p.Partition(Record{Key: StructuredData{"name": "foo", "id": 1.0}}, 100)
+			is.NoErr(err)
+			is.Equal(p1, p2)
+
+			// keys are distributed across all partitions
+			const numPartitions = 8
+			counts := make([]int, numPartitions)
+			for i := 0; i < 8000; i++ {
+				got, err := p.Partition(Record{Key: RawData(fmt.Sprintf("key-%d", i))}, numPartitions)
+				is.NoErr(err)
+				is.True(got >= 0 && got < numPartitions)
+				counts[got]++
+			}
+			for _, c := range counts {
+				is.True(c > 500) // roughly 1000 keys per partition
+			}
+		})
+	}
+}
+
+func TestPartitioner_StructuredKeys(t *testing.T) {
+	// Golden values pin the partitions of structured keys, which depend on
+	// the canonical encoding. Changing them moves keys to other partitions.
+	testCases := []struct {
+		key            StructuredData
+		wantMurmur2    int
+		wantFNV        int
+		wantConsistent int
+	}{
+		{key: StructuredData{"id": 1}, wantMurmur2: 16, wantFNV: 19, wantConsistent: 74},
+		{key: StructuredData{"id": []byte("foo")}, wantMurmur2: 6, wantFNV: 23, wantConsistent: 34},
+		{key: StructuredData{"id": "Zm9v"}, wantMurmur2: 0, wantFNV: 74, wantConsistent: 73},
+		{key: StructuredData{"id": math.NaN()}, wantMurmur2: 42, wantFNV: 7, wantConsistent: 34},
+		{key: StructuredData{"id": "NaN"}, wantMurmur2: 90, wantFNV: 39, wantConsistent: 70},
+		{key: StructuredData{"id": time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}, wantMurmur2: 80, wantFNV: 42, wantConsistent: 28},
+		{key: StructuredData{"id": "2024-01-02T03:04:05Z"}, wantMurmur2: 15, wantFNV: 37, wantConsistent: 96},
+		{key: StructuredData{"name": "foo", "id": 1.5}, wantMurmur2: 65, wantFNV: 11, wantConsistent: 31},
+	}
+	for _, tc := range testCases {
+		t.Run(string(tc.key.CanonicalBytes()), func(t *testing.T) {
+			is := is.New(t)
+			r := Record{Key: tc.key}
+
+			got, err := Murmur2Partitioner{}.Partition(r, 100)
+			is.NoErr(err)
+			is.Equal(got, tc.wantMurmur2)
+
+			got, err = FNVPartitioner{}.Partition(r, 100)
+			is.NoErr(err)
+			is.Equal(got, tc.wantFNV)
+
+			got, err = NewConsistentHashPartitioner(0).Partition(r, 100)
+			is.NoErr(err)
+			is.Equal(got, tc.wantConsistent)
+		})
+	}
+}
+
+func TestConsistentHashPartitioner_Rebalance(t *testing.T) {
+	is := is.New(t)
+	p := NewConsistentHashPartitioner(0)
+
+	const keys = 10000
+	moved := 0
+	for i := 0; i < keys; i++ {
+		r := Record{Key: RawData(fmt.Sprintf("key-%d", i))}
+		before, err := p.Partition(r, 10)
+		is.NoErr(err)
+		after, err := p.Partition(r, 11)
+		is.NoErr(err)
+		if before != after {
+			is.Equal(after, 10) // keys only move to the new partition
+			moved++
+		}
+	}
+	// about 1/11 of the keys move, a modulo partitioner would move most keys
+	is.True(moved > keys/20 && moved < keys/5)
+}
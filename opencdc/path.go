@@ -0,0 +1,640 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type pathRoot int
+
+const (
+	pathRootPosition pathRoot = iota + 1
+	pathRootOperation
+	pathRootMetadata
+	pathRootKey
+	pathRootPayloadBefore
+	pathRootPayloadAfter
+)
+
+// Path is a parsed reference to a value inside a Record. A path starts with a
+// record field and can address values nested inside of StructuredData. Fields
+// can be addressed with a dot or with a quoted name in brackets, slice
+// elements can be addressed with an index in brackets. Examples of valid
+// paths:
+//
+//	.Position
+//	.Operation
+//	.Metadata
+//	.Metadata.foo
+//	.Metadata["opencdc.collection"]
+//	.Key
+//	.Key.id
+//	.Payload.Before
+//	.Payload.After.customer.address.city
+//	.Payload.After["field with spaces"]
+//	.Payload.After.items[0]
+//
+// The zero value is not a valid path, use ParsePath to create one.
+type Path struct {
+	raw    string
+	root   pathRoot
+	fields []pathField
+}
+
+// pathField is a single element of a path that either references a field in a
+// map or an element in a slice.
+type pathField struct {
+	name    string
+	index   int
+	isIndex bool
+}
+
+func (f pathField) String() string {
+	if f.isIndex {
+		return "[" + strconv.Itoa(f.index) + "]"
+	}
+	if f.name == "" || strings.ContainsAny(f.name, ".[]\" \t\n") {
+		return "[" + strconv.Quote(f.name) + "]"
+	}
+	return "." + f.name
+}
+
+// ParsePath parses the textual representation of a path. It returns
+// ErrInvalidPath if the path is malformed.
+func ParsePath(path string) (Path, error) {
+	fields, err := parsePathFields(path)
+	if err != nil {
+		return Path{}, fmt.Errorf("path %q: %w", path, err)
+	}
+
+	p := Path{raw: path}
+	if len(fields) == 0 || fields[0].isIndex {
+		return Path{}, fmt.Errorf("path %q: missing record field: %w", path, ErrInvalidPath)
+	}
+	switch fields[0].name {
+	case "Position":
+		p.root = pathRootPosition
+	case "Operation":
+		p.root = pathRootOperation
+	case "Metadata":
+		p.root = pathRootMetadata
+	case "Key":
+		p.root = pathRootKey
+	case "Payload":
+		if len(fields) < 2 || fields[1].isIndex {
+			return Path{}, fmt.Errorf("path %q: expected .Payload.Before or .Payload.After: %w", path, ErrInvalidPath)
+		}
+		switch fields[1].name {
+		case "Before":
+			p.root = pathRootPayloadBefore
+		case "After":
+			p.root = pathRootPayloadAfter
+		default:
+			return Path{}, fmt.Errorf("path %q: expected .Payload.Before or .Payload.After: %w", path, ErrInvalidPath)
+		}
+		fields = fields[1:]
+	default:
+		return Path{}, fmt.Errorf("path %q: unknown record field %q: %w", path, fields[0].name, ErrInvalidPath)
+	}
+	p.fields = fields[1:]
+
+	switch p.root {
+	case pathRootPosition, pathRootOperation:
+		if len(p.fields) > 0 {
+			return Path{}, fmt.Errorf("path %q: %s does not contain fields: %w", path, p.rootString(), ErrInvalidPath)
+		}
+	case pathRootMetadata:
+		if len(p.fields) > 1 || (len(p.fields) == 1 && p.fields[0].isIndex) {
+			return Path{}, fmt.Errorf("path %q: metadata can only be addressed with a single key: %w", path, ErrInvalidPath)
+		}
+	case pathRootKey, pathRootPayloadBefore, pathRootPayloadAfter:
+		// any fields are allowed
+	}
+
+	return p, nil
+}
+
+// MustParsePath is like ParsePath but panics if the path can't be parsed.
+func MustParsePath(path string) Path {
+	p, err := ParsePath(path)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// parsePathFields splits a path into its fields.
+func parsePathFields(path string) ([]pathField, error) {
+	var fields []pathField
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			j := i + 1
+			for j < len(path) && path[j] != '.' && path[j] != '[' {
+				j++
+			}
+			name := path[i+1 : j]
+			if name == "" {
+				return nil, fmt.Errorf("empty field name at offset %d: %w", i, ErrInvalidPath)
+			}
+			if strings.ContainsAny(name, "]\"") {
+				return nil, fmt.Errorf("invalid field name %q: %w", name, ErrInvalidPath)
+			}
+			fields = append(fields, pathField{name: name})
+			i = j
+		case '[':
+			if i+1 < len(path) && path[i+1] == '"' {
+				quoted, err := strconv.QuotedPrefix(path[i+1:])
+				if err != nil {
+					return nil, fmt.Errorf("invalid quoted field name at offset %d: %w", i, ErrInvalidPath)
+				}
+				name, _ := strconv.Unquote(quoted) // QuotedPrefix already validated it
+				end := i + 1 + len(quoted)
+				if end >= len(path) || path[end] != ']' {
+					return nil, fmt.Errorf("missing closing bracket at offset %d: %w", end, ErrInvalidPath)
+				}
+				fields = append(fields, pathField{name: name})
+				i = end + 1
+				continue
+			}
+			end := strings.IndexByte(path[i:], ']')
+			if end == -1 {
+				return nil, fmt.Errorf("missing closing bracket at offset %d: %w", i, ErrInvalidPath)
+			}
+			index, err := strconv.Atoi(path[i+1 : i+end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid index %q: %w", path[i+1:i+end], ErrInvalidPath)
+			}
+			fields = append(fields, pathField{index: index, isIndex: true})
+			i += end + 1
+		default:
+			return nil, fmt.Errorf("unexpected character %q at offset %d: %w", path[i], i, ErrInvalidPath)
+		}
+	}
+	return fields, nil
+}
+
+// String returns the textual representation of the path.
+func (p Path) String() string {
+	return p.raw
+}
+
+func (p Path) rootString() string {
+	switch p.root {
+	case pathRootPosition:
+		return ".Position"
+	case pathRootOperation:
+		return ".Operation"
+	case pathRootMetadata:
+		return ".Metadata"
+	case pathRootKey:
+		return ".Key"
+	case pathRootPayloadBefore:
+		return ".Payload.Before"
+	case pathRootPayloadAfter:
+		return ".Payload.After"
+	default:
+		return ""
+	}
+}
+
+// data returns a pointer to the Data field the path is rooted in, or nil if
+// the path does not point into Data.
+func (p Path) data(r *Record) *Data {
+	switch p.root { //nolint:exhaustive // other roots don't point into data
+	case pathRootKey:
+		return &r.Key
+	case pathRootPayloadBefore:
+		return &r.Payload.Before
+	case pathRootPayloadAfter:
+		return &r.Payload.After
+	default:
+		return nil
+	}
+}
+
+// Get returns the value the path points to in record r. It returns
+// ErrFieldNotFound if the value does not exist, ErrNotStructuredData if the
+// path points into RawData and ErrInvalidFieldType if the path traverses a
+// value that can't contain fields.
+func (p Path) Get(r Record) (any, error) {
+	switch p.root {
+	case pathRootPosition:
+		return r.Position, nil
+	case pathRootOperation:
+		return r.Operation, nil
+	case pathRootMetadata:
+		if len(p.fields) == 0 {
+			return r.Metadata, nil
+		}
+		v, ok := r.Metadata[p.fields[0].name]
+		if !ok {
+			return nil, fmt.Errorf("path %q: %w", p.raw, ErrFieldNotFound)
+		}
+		return v, nil
+	case pathRootKey, pathRootPayloadBefore, pathRootPayloadAfter:
+		d := *p.data(&r)
+		if len(p.fields) == 0 {
+			return d, nil
+		}
+		switch d := d.(type) {
+		case nil:
+			return nil, fmt.Errorf("path %q: %w", p.raw, ErrFieldNotFound)
+		case RawData:
+			return nil, fmt.Errorf("path %q: %w", p.raw, ErrNotStructuredData)
+		case StructuredData:
+			var cur any = d
+			for i, f := range p.fields {
+				next, err := getField(cur, f)
+				if err != nil {
+					return nil, fmt.Errorf("path %q: field %s: %w", p.raw, p.fieldsString(i), err)
+				}
+				cur = next
+			}
+			return cur, nil
+		}
+	}
+	return nil, fmt.Errorf("path %q: %w", p.raw, ErrInvalidPath)
+}
+
+// Lookup returns the value the path points to in record r and reports whether
+// it exists. It returns false if the value does not exist, the path points
+// into RawData or the path traverses a value that can't contain fields.
+// Unlike Get it doesn't allocate an error for missing values, which makes it
+// suitable for hot paths, e.g. when evaluating a path for every record.
+func (p Path) Lookup(r Record) (any, bool) {
+	switch p.root {
+	case pathRootPosition:
+		return r.Position, true
+	case pathRootOperation:
+		return r.Operation, true
+	case pathRootMetadata:
+		if len(p.fields) == 0 {
+			return r.Metadata, true
+		}
+		v, ok := r.Metadata[p.fields[0].name]
+		return v, ok
+	case pathRootKey, pathRootPayloadBefore, pathRootPayloadAfter:
+		d := *p.data(&r)
+		if len(p.fields) == 0 {
+			return d, true
+		}
+		sd, ok := d.(StructuredData)
+		if !ok {
+			return nil, false
+		}
+		var cur any = sd
+		for _, f := range p.fields {
+			next, err := lookupField(cur, f)
+			if err != nil {
+				return nil, false
+			}
+			cur = next
+		}
+		return cur, true
+	}
+	return nil, false
+}
+
+// Set sets the value the path points to in record r to v. Missing maps on the
+// way to the value are created. Values at the root of the record need to have
+// the type of the record field, with these exceptions: a string or byte slice
+// can be used for .Position, a string can be used for .Operation and
+// .Metadata, a map[string]any can be used for StructuredData and a byte slice
+// or string can be used for RawData. It returns ErrNotStructuredData if the
+// path points into RawData and ErrInvalidFieldType if the value can't be set
+// because of its type or the type of a value on the way.
+func (p Path) Set(r *Record, v any) error {
+	switch p.root {
+	case pathRootPosition:
+		switch v := v.(type) {
+		case Position:
+			r.Position = v
+		case []byte:
+			r.Position = v
+		case string:
+			r.Position = Position(v)
+		case nil:
+			r.Position = nil
+		default:
+			return fmt.Errorf("path %q: can't set value of type %T: %w", p.raw, v, ErrInvalidFieldType)
+		}
+		return nil
+	case pathRootOperation:
+		switch v := v.(type) {
+		case Operation:
+			r.Operation = v
+		case string:
+			var op Operation
+			err := op.UnmarshalText([]byte(v))
+			if err != nil {
+				return fmt.Errorf("path %q: %w", p.raw, err)
+			}
+			r.Operation = op
+		default:
+			return fmt.Errorf("path %q: can't set value of type %T: %w", p.raw, v, ErrInvalidFieldType)
+		}
+		return nil
+	case pathRootMetadata:
+		if len(p.fields) == 0 {
+			switch v := v.(type) {
+			case Metadata:
+				r.Metadata = v
+			case map[string]string:
+				r.Metadata = v
+			case nil:
+				r.Metadata = nil
+			default:
+				return fmt.Errorf("path %q: can't set value of type %T: %w", p.raw, v, ErrInvalidFieldType)
+			}
+			return nil
+		}
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("path %q: can't set value of type %T: %w", p.raw, v, ErrInvalidFieldType)
+		}
+		if r.Metadata == nil {
+			r.Metadata = Metadata{}
+		}
+		r.Metadata[p.fields[0].name] = s
+		return nil
+	case pathRootKey, pathRootPayloadBefore, pathRootPayloadAfter:
+		d := p.data(r)
+		if len(p.fields) == 0 {
+			switch v := v.(type) {
+			case Data:
+				*d = v
+			case map[string]any:
+				*d = StructuredData(v)
+			case []byte:
+				*d = RawData(v)
+			case string:
+				*d = RawData(v)
+			case nil:
+				*d = nil
+			default:
+				return fmt.Errorf("path %q: can't set value of type %T: %w", p.raw, v, ErrInvalidFieldType)
+			}
+			return nil
+		}
+
+		var sd StructuredData
+		switch dd := (*d).(type) {
+		case nil:
+			sd = StructuredData{}
+			*d = sd
+		case RawData:
+			return fmt.Errorf("path %q: %w", p.raw, ErrNotStructuredData)
+		case StructuredData:
+			if dd == nil {
+				dd = StructuredData{}
+				*d = dd
+			}
+			sd = dd
+		}
+
+		var cur any = sd
+		last := len(p.fields) - 1
+		for i, f := range p.fields[:last] {
+			next, err := getField(cur, f)
+			if err != nil && (!isFieldNotFound(err) || f.isIndex || p.fields[i+1].isIndex) {
+				return fmt.Errorf("path %q: field %s: %w", p.raw, p.fieldsString(i), err)
+			}
+			if !p.fields[i+1].isIndex {
+				switch rv := reflect.ValueOf(next); {
+				case next == nil:
+					// Create missing map.
+					next = map[string]any{}
+				case rv.Kind() == reflect.Map && rv.IsNil():
+					// Replace nil map with an empty map of the same type.
+					next = reflect.MakeMap(rv.Type()).Interface()
+				default:
+					cur = next
+					continue
+				}
+				err = setField(cur, f, next)
+				if err != nil {
+					return fmt.Errorf("path %q: field %s: %w", p.raw, p.fieldsString(i), err)
+				}
+			}
+			cur = next
+		}
+		err := setField(cur, p.fields[last], v)
+		if err != nil {
+			return fmt.Errorf("path %q: field %s: %w", p.raw, p.fieldsString(last), err)
+		}
+		return nil
+	}
+	return fmt.Errorf("path %q: %w", p.raw, ErrInvalidPath)
+}
+
+// Delete removes the value the path points to from record r. Record fields
+// are set to their zero value, fields in maps are removed. Deleting a value
+// that does not exist is a no-op. Elements of slices and the operation can't
+// be deleted, in which case the function returns ErrInvalidFieldType.
+func (p Path) Delete(r *Record) error {
+	switch p.root {
+	case pathRootPosition:
+		r.Position = nil
+		return nil
+	case pathRootOperation:
+		return fmt.Errorf("path %q: operation can't be deleted: %w", p.raw, ErrInvalidFieldType)
+	case pathRootMetadata:
+		if len(p.fields) == 0 {
+			r.Metadata = nil
+			return nil
+		}
+		delete(r.Metadata, p.fields[0].name)
+		return nil
+	case pathRootKey, pathRootPayloadBefore, pathRootPayloadAfter:
+		d := p.data(r)
+		if len(p.fields) == 0 {
+			*d = nil
+			return nil
+		}
+
+		var cur any
+		switch dd := (*d).(type) {
+		case nil:
+			return nil
+		case RawData:
+			return fmt.Errorf("path %q: %w", p.raw, ErrNotStructuredData)
+		case StructuredData:
+			cur = dd
+		}
+
+		last := len(p.fields) - 1
+		for i, f := range p.fields[:last] {
+			next, err := getField(cur, f)
+			if err != nil {
+				if isFieldNotFound(err) {
+					return nil
+				}
+				return fmt.Errorf("path %q: field %s: %w", p.raw, p.fieldsString(i), err)
+			}
+			cur = next
+		}
+		err := deleteField(cur, p.fields[last])
+		if err != nil {
+			return fmt.Errorf("path %q: field %s: %w", p.raw, p.fieldsString(last), err)
+		}
+		return nil
+	}
+	return fmt.Errorf("path %q: %w", p.raw, ErrInvalidPath)
+}
+
+// fieldsString returns the textual representation of the path up to and
+// including the field with index i.
+func (p Path) fieldsString(i int) string {
+	var sb strings.Builder
+	sb.WriteString(p.rootString())
+	for _, f := range p.fields[:i+1] {
+		sb.WriteString(f.String())
+	}
+	return sb.String()
+}
+
+func isFieldNotFound(err error) bool {
+	return err == ErrFieldNotFound //nolint:errorlint // errors returned by getField are not wrapped
+}
+
+// getField returns the value of field f in the container cur, which is
+// expected to be a map with string keys or a slice.
+func getField(cur any, f pathField) (any, error) {
+	v, err := lookupField(cur, f)
+	if err == ErrInvalidFieldType { //nolint:errorlint // errors returned by lookupField are not wrapped
+		return nil, fmt.Errorf("can't get field %s from value of type %T: %w", f, cur, err)
+	}
+	return v, err
+}
+
+// lookupField is like getField, but returns the bare sentinel errors without
+// allocating.
+func lookupField(cur any, f pathField) (any, error) {
+	if !f.isIndex {
+		switch m := cur.(type) {
+		case StructuredData:
+			v, ok := m[f.name]
+			if !ok {
+				return nil, ErrFieldNotFound
+			}
+			return v, nil
+		case map[string]any:
+			v, ok := m[f.name]
+			if !ok {
+				return nil, ErrFieldNotFound
+			}
+			return v, nil
+		}
+	}
+
+	rv := reflect.ValueOf(cur)
+	switch {
+	case !f.isIndex && rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		v := rv.MapIndex(reflect.ValueOf(f.name).Convert(rv.Type().Key()))
+		if !v.IsValid() {
+			return nil, ErrFieldNotFound
+		}
+		return v.Interface(), nil
+	case f.isIndex && (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array):
+		if f.index >= rv.Len() {
+			return nil, ErrFieldNotFound
+		}
+		return rv.Index(f.index).Interface(), nil
+	default:
+		return nil, ErrInvalidFieldType
+	}
+}
+
+// setField sets the value of field f in the container cur to v.
+func setField(cur any, f pathField, v any) error {
+	if !f.isIndex {
+		switch m := cur.(type) {
+		case StructuredData:
+			m[f.name] = v
+			return nil
+		case map[string]any:
+			m[f.name] = v
+			return nil
+		}
+	}
+
+	rv := reflect.ValueOf(cur)
+	switch {
+	case !f.isIndex && rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		val, err := assignableValue(v, rv.Type().Elem())
+		if err != nil {
+			return err
+		}
+		rv.SetMapIndex(reflect.ValueOf(f.name).Convert(rv.Type().Key()), val)
+		return nil
+	case f.isIndex && rv.Kind() == reflect.Slice:
+		if f.index >= rv.Len() {
+			return fmt.Errorf("index %d out of range: %w", f.index, ErrFieldNotFound)
+		}
+		val, err := assignableValue(v, rv.Type().Elem())
+		if err != nil {
+			return err
+		}
+		rv.Index(f.index).Set(val)
+		return nil
+	default:
+		return fmt.Errorf("can't set field %s in value of type %T: %w", f, cur, ErrInvalidFieldType)
+	}
+}
+
+// deleteField removes field f from the container cur.
+func deleteField(cur any, f pathField) error {
+	if f.isIndex {
+		return fmt.Errorf("can't delete slice element %s: %w", f, ErrInvalidFieldType)
+	}
+	switch m := cur.(type) {
+	case StructuredData:
+		delete(m, f.name)
+		return nil
+	case map[string]any:
+		delete(m, f.name)
+		return nil
+	}
+
+	rv := reflect.ValueOf(cur)
+	if rv.Kind() != reflect.Map || rv.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("can't delete field %s from value of type %T: %w", f, cur, ErrInvalidFieldType)
+	}
+	rv.SetMapIndex(reflect.ValueOf(f.name).Convert(rv.Type().Key()), reflect.Value{})
+	return nil
+}
+
+// assignableValue returns v as a reflect.Value that can be assigned to a value
+// of type t.
+func assignableValue(v any, t reflect.Type) (reflect.Value, error) {
+	if v == nil {
+		switch t.Kind() { //nolint:exhaustive // only nillable kinds are relevant
+		case reflect.Interface, reflect.Map, reflect.Slice, reflect.Pointer, reflect.Func, reflect.Chan:
+			return reflect.Zero(t), nil
+		default:
+			return reflect.Value{}, fmt.Errorf("can't assign nil to value of type %s: %w", t, ErrInvalidFieldType)
+		}
+	}
+	val := reflect.ValueOf(v)
+	if !val.Type().AssignableTo(t) {
+		return reflect.Value{}, fmt.Errorf("can't assign value of type %T to value of type %s: %w", v, t, ErrInvalidFieldType)
+	}
+	return val, nil
+}
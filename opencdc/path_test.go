@@ -0,0 +1,338 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/matryer/is"
+)
+
+func testPathRecord() Record {
+	return Record{
+		Position:  Position("standing"),
+		Operation: OperationUpdate,
+		Metadata:  Metadata{"opencdc.collection": "users", "foo": "bar"},
+		Key:       RawData("padlock-key"),
+		Payload: Change{
+			Before: StructuredData{"id": 1},
+			After: StructuredData{
+				"id":          1,
+				"field name":  "spaces",
+				"tags":        []any{"a", "b"},
+				"strings":     []string{"c", "d"},
+				"labels":      map[string]string{"env": "prod"},
+				"nil":         nil,
+				"customer":    map[string]any{"address": StructuredData{"city": "Amsterdam"}},
+				"description": "foo",
+			},
+		},
+	}
+}
+
+func TestParsePath_Invalid(t *testing.T) {
+	testCases := []string{
+		"",
+		"Payload",
+		".",
+		".Foo",
+		".Payload",
+		".Payload.Middle",
+		".Position.foo",
+		".Operation[0]",
+		".Metadata.foo.bar",
+		".Metadata[0]",
+		".Key..foo",
+		".Key[",
+		".Key[-1]",
+		".Key[foo]",
+		`.Key["foo"`,
+		`.Key["foo]`,
+		`.Key.foo"`,
+		"[0]",
+	}
+	for _, tc := range testCases {
+		t.Run(tc, func(t *testing.T) {
+			is := is.New(t)
+			_, err := ParsePath(tc)
+			is.True(errors.Is(err, ErrInvalidPath))
+		})
+	}
+}
+
+func TestPath_Get(t *testing.T) {
+	testCases := []struct {
+		path    string
+		want    any
+		wantErr error
+	}{
+		{path: ".Position", want: Position("standing")},
+		{path: ".Operation", want: OperationUpdate},
+		{path: ".Metadata", want: Metadata{"opencdc.collection": "users", "foo": "bar"}},
+		{path: ".Metadata.foo", want: "bar"},
+		{path: `.Metadata["opencdc.collection"]`, want: "users"},
+		{path: ".Metadata.missing", wantErr: ErrFieldNotFound},
+		{path: ".Key", want: RawData("padlock-key")},
+		{path: ".Key.foo", wantErr: ErrNotStructuredData},
+		{path: ".Payload.Before", want: StructuredData{"id": 1}},
+		{path: ".Payload.After.id", want: 1},
+		{path: `.Payload.After["field name"]`, want: "spaces"},
+		{path: ".Payload.After.tags[1]", want: "b"},
+		{path: ".Payload.After.strings[0]", want: "c"},
+		{path: ".Payload.After.labels.env", want: "prod"},
+		{path: ".Payload.After.nil", want: nil},
+		{path: ".Payload.After.customer.address.city", want: "Amsterdam"},
+		{path: `.Payload["After"]["customer"]["address"]["city"]`, want: "Amsterdam"},
+		{path: ".Payload.After.missing", wantErr: ErrFieldNotFound},
+		{path: ".Payload.After.customer.missing.city", wantErr: ErrFieldNotFound},
+		{path: ".Payload.After.tags[2]", wantErr: ErrFieldNotFound},
+		{path: ".Payload.After.description.foo", wantErr: ErrInvalidFieldType},
+		{path: ".Payload.After.customer[0]", wantErr: ErrInvalidFieldType},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			is := is.New(t)
+			p, err := ParsePath(tc.path)
+			is.NoErr(err)
+			is.Equal(p.String(), tc.path)
+
+			// Lookup reports missing values instead of returning an error
+			lookedUp, ok := p.Lookup(testPathRecord())
+			is.Equal(ok, tc.wantErr == nil)
+
+			got, err := p.Get(testPathRecord())
+			if tc.wantErr != nil {
+				is.True(errors.Is(err, tc.wantErr))
+				return
+			}
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+			is.Equal(lookedUp, tc.want)
+		})
+	}
+}
+
+func TestPath_Set(t *testing.T) {
+	testCases := []struct {
+		path    string
+		value   any
+		want    func(*Record)
+		wantErr error
+	}{{
+		path:  ".Position",
+		value: "new-position",
+		want:  func(r *Record) { r.Position = Position("new-position") },
+	}, {
+		path:  ".Operation",
+		value: "delete",
+		want:  func(r *Record) { r.Operation = OperationDelete },
+	}, {
+		path:    ".Operation",
+		value:   1,
+		wantErr: ErrInvalidFieldType,
+	}, {
+		path:  `.Metadata["opencdc.collection"]`,
+		value: "customers",
+		want:  func(r *Record) { r.Metadata["opencdc.collection"] = "customers" },
+	}, {
+		path:    ".Metadata.foo",
+		value:   1,
+		wantErr: ErrInvalidFieldType,
+	}, {
+		path:  ".Key",
+		value: map[string]any{"id": 2},
+		want:  func(r *Record) { r.Key = StructuredData{"id": 2} },
+	}, {
+		path:    ".Key.id",
+		value:   2,
+		wantErr: ErrNotStructuredData,
+	}, {
+		path:  ".Payload.After.id",
+		value: 2,
+		want: func(r *Record) {
+			r.Payload.After.(StructuredData)["id"] = 2
+		},
+	}, {
+		path:  ".Payload.After.customer.address.city",
+		value: "Ljubljana",
+		want: func(r *Record) {
+			r.Payload.After.(StructuredData)["customer"] = map[string]any{"address": StructuredData{"city": "Ljubljana"}}
+		},
+	}, {
+		path:  ".Payload.After.customer.phone.mobile",
+		value: "123",
+		want: func(r *Record) {
+			r.Payload.After.(StructuredData)["customer"] = map[string]any{
+				"address": StructuredData{"city": "Amsterdam"},
+				"phone":   map[string]any{"mobile": "123"},
+			}
+		},
+	}, {
+		path:  ".Payload.After.nil.foo",
+		value: "bar",
+		want: func(r *Record) {
+			r.Payload.After.(StructuredData)["nil"] = map[string]any{"foo": "bar"}
+		},
+	}, {
+		path:  ".Payload.After.tags[0]",
+		value: "z",
+		want: func(r *Record) {
+			r.Payload.After.(StructuredData)["tags"] = []any{"z", "b"}
+		},
+	}, {
+		path:    ".Payload.After.strings[0]",
+		value:   1,
+		wantErr: ErrInvalidFieldType,
+	}, {
+		path:    ".Payload.After.tags[5]",
+		value:   "z",
+		wantErr: ErrFieldNotFound,
+	}, {
+		path:    ".Payload.After.missing[0]",
+		value:   "z",
+		wantErr: ErrFieldNotFound,
+	}, {
+		path:    ".Payload.After.description.foo",
+		value:   "bar",
+		wantErr: ErrInvalidFieldType,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			is := is.New(t)
+			p := MustParsePath(tc.path)
+
+			got := testPathRecord()
+			err := p.Set(&got, tc.value)
+			if tc.wantErr != nil {
+				is.True(errors.Is(err, tc.wantErr))
+				return
+			}
+			is.NoErr(err)
+
+			want := testPathRecord()
+			tc.want(&want)
+			is.Equal(cmp.Diff(want, got, exactRecord), "")
+		})
+	}
+}
+
+func TestPath_Set_CreatesData(t *testing.T) {
+	is := is.New(t)
+
+	var r Record
+	is.NoErr(MustParsePath(".Payload.After.customer.address.city").Set(&r, "Amsterdam"))
+	is.NoErr(MustParsePath(".Metadata.foo").Set(&r, "bar"))
+
+	want := Record{
+		Metadata: Metadata{"foo": "bar"},
+		Payload: Change{
+			After: StructuredData{
+				"customer": map[string]any{"address": map[string]any{"city": "Amsterdam"}},
+			},
+		},
+	}
+	is.Equal(cmp.Diff(want, r, exactRecord), "")
+}
+
+func TestPath_Set_NilMaps(t *testing.T) {
+	is := is.New(t)
+
+	r := Record{Payload: Change{After: StructuredData{
+		"typed":      map[string]string(nil),
+		"generic":    map[string]any(nil),
+		"structured": StructuredData(nil),
+		"nested":     map[string]map[string]int{"a": nil},
+	}}}
+	is.NoErr(MustParsePath(".Payload.After.typed.x").Set(&r, "foo"))
+	is.NoErr(MustParsePath(".Payload.After.generic.x").Set(&r, "foo"))
+	is.NoErr(MustParsePath(".Payload.After.structured.x").Set(&r, "foo"))
+	is.NoErr(MustParsePath(".Payload.After.nested.a.x").Set(&r, 1))
+
+	is.Equal(r.Payload.After, StructuredData{
+		"typed":      map[string]string{"x": "foo"},
+		"generic":    map[string]any{"x": "foo"},
+		"structured": StructuredData{"x": "foo"},
+		"nested":     map[string]map[string]int{"a": {"x": 1}},
+	})
+
+	// deleting from nil maps is a no-op
+	r = Record{Payload: Change{After: StructuredData{"typed": map[string]string(nil)}}}
+	is.NoErr(MustParsePath(".Payload.After.typed.x").Delete(&r))
+}
+
+func TestPath_Delete(t *testing.T) {
+	testCases := []struct {
+		path    string
+		want    func(*Record)
+		wantErr error
+	}{{
+		path: ".Position",
+		want: func(r *Record) { r.Position = nil },
+	}, {
+		path:    ".Operation",
+		wantErr: ErrInvalidFieldType,
+	}, {
+		path: ".Metadata.foo",
+		want: func(r *Record) { delete(r.Metadata, "foo") },
+	}, {
+		path: ".Metadata.missing",
+		want: func(*Record) {},
+	}, {
+		path: ".Key",
+		want: func(r *Record) { r.Key = nil },
+	}, {
+		path:    ".Key.foo",
+		wantErr: ErrNotStructuredData,
+	}, {
+		path: ".Payload.After.customer.address.city",
+		want: func(r *Record) {
+			r.Payload.After.(StructuredData)["customer"] = map[string]any{"address": StructuredData{}}
+		},
+	}, {
+		path: ".Payload.After.labels.env",
+		want: func(r *Record) {
+			r.Payload.After.(StructuredData)["labels"] = map[string]string{}
+		},
+	}, {
+		path: ".Payload.After.missing.foo",
+		want: func(*Record) {},
+	}, {
+		path:    ".Payload.After.tags[0]",
+		wantErr: ErrInvalidFieldType,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			is := is.New(t)
+			p := MustParsePath(tc.path)
+
+			got := testPathRecord()
+			err := p.Delete(&got)
+			if tc.wantErr != nil {
+				is.True(errors.Is(err, tc.wantErr))
+				return
+			}
+			is.NoErr(err)
+
+			want := testPathRecord()
+			tc.want(&want)
+			is.Equal(cmp.Diff(want, got, exactRecord), "")
+		})
+	}
+}
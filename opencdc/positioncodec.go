@@ -0,0 +1,227 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/goccy/go-json"
+)
+
+// PositionMigration converts the payload of a position from one version to
+// the next.
+type PositionMigration func([]byte) ([]byte, error)
+
+// PositionCodec encodes typed positions as JSON together with a version tag.
+// When decoding a position with an older version, the migrations are applied
+// in order until the payload reaches the current version.
+//
+// Positions that were not encoded by a PositionCodec are treated as version
+// 0, their payload is the whole position. This way a connector can start
+// using the codec and migrate positions it produced before.
+type PositionCodec[T any] struct {
+	// Version is the current version of the position. Encode tags positions
+	// with this version.
+	Version int
+	// Migrations contains the migration for each version older than Version,
+	// where Migrations[v] converts the payload from version v to version v+1.
+	Migrations map[int]PositionMigration
+	// Compare returns a negative number if a is before b, a positive number if
+	// a is after b and 0 if the positions are equal. It is optional, if not
+	// set positions can't be compared.
+	Compare func(a, b T) int
+}
+
+// positionEnvelope is the JSON representation of a versioned position.
+type positionEnvelope struct {
+	Version *int            `json:"v"`
+	Payload json.RawMessage `json:"p"`
+}
+
+// Encode encodes the value and tags it with the current version.
+func (c PositionCodec[T]) Encode(v T) (Position, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode position: %w", err)
+	}
+	pos, err := json.Marshal(positionEnvelope{Version: &c.Version, Payload: payload})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode position: %w", err)
+	}
+	return pos, nil
+}
+
+// Decode decodes the position, migrating it to the current version if needed.
+// It returns ErrInvalidPosition if the position has a version newer than the
+// current version or if a migration is missing.
+func (c PositionCodec[T]) Decode(p Position) (T, error) {
+	var zero T
+	version, payload := splitPosition(p)
+	if version > c.Version {
+		return zero, fmt.Errorf("position version %d is newer than supported version %d: %w", version, c.Version, ErrInvalidPosition)
+	}
+	for ; version < c.Version; version++ {
+		migrate, ok := c.Migrations[version]
+		if !ok {
+			return zero, fmt.Errorf("missing migration for position version %d: %w", version, ErrInvalidPosition)
+		}
+		var err error
+		payload, err = migrate(payload)
+		if err != nil {
+			return zero, fmt.Errorf("failed to migrate position from version %d: %w", version, err)
+		}
+	}
+
+	var v T
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return zero, fmt.Errorf("failed to decode position: %w: %w", ErrInvalidPosition, err)
+	}
+	return v, nil
+}
+
+// ComparePositions decodes both positions and compares them using Compare.
+// It returns ErrPositionsNotComparable if Compare is not set.
+func (c PositionCodec[T]) ComparePositions(a, b Position) (int, error) {
+	if c.Compare == nil {
+		return 0, ErrPositionsNotComparable
+	}
+	va, err := c.Decode(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := c.Decode(b)
+	if err != nil {
+		return 0, err
+	}
+	return c.Compare(va, vb), nil
+}
+
+// splitPosition returns the version and payload of a position. Positions
+// without a version tag are version 0.
+func splitPosition(p Position) (int, []byte) {
+	var env positionEnvelope
+	if err := json.Unmarshal(p, &env); err != nil || env.Version == nil || env.Payload == nil {
+		return 0, p
+	}
+	return *env.Version, env.Payload
+}
+
+// CompositePosition is a position made of multiple sub-positions, e.g. one
+// per collection in a source that reads multiple collections.
+type CompositePosition map[string]Position
+
+// ParseCompositePosition parses a position produced by
+// CompositePosition.ToPosition. A nil position results in an empty composite
+// position.
+func ParseCompositePosition(p Position) (CompositePosition, error) {
+	if len(p) == 0 {
+		return CompositePosition{}, nil
+	}
+	var raw map[string][]byte
+	if err := json.Unmarshal(p, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse composite position: %w: %w", ErrInvalidPosition, err)
+	}
+	c := make(CompositePosition, len(raw))
+	for k, v := range raw {
+		c[k] = v
+	}
+	return c, nil
+}
+
+// ToPosition encodes the composite position. Keys are sorted, so equal
+// composite positions produce equal positions.
+func (c CompositePosition) ToPosition() (Position, error) {
+	raw := make(map[string][]byte, len(c))
+	for k, v := range c {
+		raw[k] = v
+	}
+	pos, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode composite position: %w", err)
+	}
+	return pos, nil
+}
+
+// Clone returns a copy of the composite position.
+func (c CompositePosition) Clone() CompositePosition {
+	if c == nil {
+		return nil
+	}
+	clone := make(CompositePosition, len(c))
+	for k, v := range c {
+		clone[k] = bytes.Clone(v)
+	}
+	return clone
+}
+
+// Keys returns the keys of the sub-positions in sorted order.
+func (c CompositePosition) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Compare compares two composite positions using cmp to compare the
+// sub-positions with the same key. A missing sub-position is before any
+// existing sub-position. The composite position a is before b if no
+// sub-position in a is after the one in b and at least one is before it. If
+// some sub-positions are before and others after, the positions are
+// concurrent and Compare returns ErrPositionsNotComparable.
+func (c CompositePosition) Compare(other CompositePosition, cmp func(key string, a, b Position) (int, error)) (int, error) {
+	keys := make(map[string]struct{}, len(c)+len(other))
+	for k := range c {
+		keys[k] = struct{}{}
+	}
+	for k := range other {
+		keys[k] = struct{}{}
+	}
+
+	var before, after bool
+	for k := range keys {
+		a, okA := c[k]
+		b, okB := other[k]
+		var result int
+		switch {
+		case !okA:
+			result = -1
+		case !okB:
+			result = 1
+		default:
+			var err error
+			result, err = cmp(k, a, b)
+			if err != nil {
+				return 0, fmt.Errorf("failed to compare sub-position %q: %w", k, err)
+			}
+		}
+		before = before || result < 0
+		after = after || result > 0
+	}
+
+	switch {
+	case before && after:
+		return 0, ErrPositionsNotComparable
+	case before:
+		return -1, nil
+	case after:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/matryer/is"
+)
+
+type testPosition struct {
+	Table  string `json:"table"`
+	Offset int    `json:"offset"`
+}
+
+func testPositionCodec() PositionCodec[testPosition] {
+	return PositionCodec[testPosition]{
+		Version: 2,
+		Migrations: map[int]PositionMigration{
+			// version 0 was a plain offset
+			0: func(p []byte) ([]byte, error) {
+				offset, err := strconv.Atoi(string(p))
+				if err != nil {
+					return nil, err
+				}
+				return json.Marshal(map[string]any{"off": offset})
+			},
+			// version 1 used "off" instead of "offset" and had no table
+			1: func(p []byte) ([]byte, error) {
+				var v1 struct {
+					Off int `json:"off"`
+				}
+				if err := json.Unmarshal(p, &v1); err != nil {
+					return nil, err
+				}
+				return json.Marshal(testPosition{Table: "default", Offset: v1.Off})
+			},
+		},
+		Compare: func(a, b testPosition) int { return a.Offset - b.Offset },
+	}
+}
+
+func TestPositionCodec_EncodeDecode(t *testing.T) {
+	is := is.New(t)
+	codec := testPositionCodec()
+
+	want := testPosition{Table: "users", Offset: 42}
+	pos, err := codec.Encode(want)
+	is.NoErr(err)
+	is.Equal(string(pos), `{"v":2,"p":{"table":"users","offset":42}}`)
+
+	got, err := codec.Decode(pos)
+	is.NoErr(err)
+	is.Equal(got, want)
+}
+
+func TestPositionCodec_Migrate(t *testing.T) {
+	testCases := []struct {
+		name string
+		pos  Position
+	}{{
+		name: "unversioned",
+		pos:  Position("42"),
+	}, {
+		name: "version 1",
+		pos:  Position(`{"v":1,"p":{"off":42}}`),
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			got, err := testPositionCodec().Decode(tc.pos)
+			is.NoErr(err)
+			is.Equal(got, testPosition{Table: "default", Offset: 42})
+		})
+	}
+}
+
+func TestPositionCodec_DecodeError(t *testing.T) {
+	testCases := []struct {
+		name string
+		pos  Position
+	}{{
+		name: "newer version",
+		pos:  Position(`{"v":3,"p":{}}`),
+	}, {
+		name: "invalid payload",
+		pos:  Position(`{"v":2,"p":"foo"}`),
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			_, err := testPositionCodec().Decode(tc.pos)
+			is.True(errors.Is(err, ErrInvalidPosition))
+		})
+	}
+
+	is := is.New(t)
+	codec := testPositionCodec()
+	delete(codec.Migrations, 0)
+	_, err := codec.Decode(Position("42"))
+	is.True(errors.Is(err, ErrInvalidPosition))
+}
+
+func TestPositionCodec_ComparePositions(t *testing.T) {
+	is := is.New(t)
+	codec := testPositionCodec()
+
+	p1, err := codec.Encode(testPosition{Offset: 1})
+	is.NoErr(err)
+	got, err := codec.ComparePositions(p1, Position("2"))
+	is.NoErr(err)
+	is.True(got < 0)
+
+	codec.Compare = nil
+	_, err = codec.ComparePositions(p1, p1)
+	is.True(errors.Is(err, ErrPositionsNotComparable))
+}
+
+func TestCompositePosition_ToPosition(t *testing.T) {
+	is := is.New(t)
+
+	c := CompositePosition{
+		"users":  Position("1"),
+		"orders": Position("2"),
+	}
+	pos, err := c.ToPosition()
+	is.NoErr(err)
+	is.Equal(string(pos), `{"orders":"Mg==","users":"MQ=="}`)
+
+	got, err := ParseCompositePosition(pos)
+	is.NoErr(err)
+	is.Equal(got, c)
+	is.Equal(got.Keys(), []string{"orders", "users"})
+
+	got, err = ParseCompositePosition(nil)
+	is.NoErr(err)
+	is.Equal(got, CompositePosition{})
+
+	_, err = ParseCompositePosition(Position("foo"))
+	is.True(errors.Is(err, ErrInvalidPosition))
+}
+
+func TestCompositePosition_Compare(t *testing.T) {
+	cmpBytes := func(_ string, a, b Position) (int, error) {
+		return bytes.Compare(a, b), nil
+	}
+
+	testCases := []struct {
+		name    string
+		a, b    CompositePosition
+		want    int
+		wantErr error
+	}{{
+		name: "equal",
+		a:    CompositePosition{"a": Position("1"), "b": Position("1")},
+		b:    CompositePosition{"a": Position("1"), "b": Position("1")},
+		want: 0,
+	}, {
+		name: "before",
+		a:    CompositePosition{"a": Position("1"), "b": Position("1")},
+		b:    CompositePosition{"a": Position("1"), "b": Position("2")},
+		want: -1,
+	}, {
+		name: "after",
+		a:    CompositePosition{"a": Position("2"), "b": Position("1")},
+		b:    CompositePosition{"a": Position("1"), "b": Position("1")},
+		want: 1,
+	}, {
+		name: "missing sub-position",
+		a:    CompositePosition{"a": Position("1")},
+		b:    CompositePosition{"a": Position("1"), "b": Position("1")},
+		want: -1,
+	}, {
+		name:    "concurrent",
+		a:       CompositePosition{"a": Position("2"), "b": Position("1")},
+		b:       CompositePosition{"a": Position("1"), "b": Position("2")},
+		wantErr: ErrPositionsNotComparable,
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			got, err := tc.a.Compare(tc.b, cmpBytes)
+			if tc.wantErr != nil {
+				is.True(errors.Is(err, tc.wantErr))
+				return
+			}
+			is.NoErr(err)
+			is.Equal(got, tc.want)
+		})
+	}
+}
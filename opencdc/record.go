@@ -79,6 +79,23 @@ func (r Record) Bytes() []byte {
 	return b
 }
 
+// Size returns an estimate of the size of the record in bytes. It sums up the
+// sizes of the position, operation, metadata, key and payload without
+// serializing the record, which makes it cheap enough to be called for every
+// record, e.g. when batching records by size.
+func (r Record) Size() int {
+	size := len(r.Position) + len(r.Operation.String())
+	for k, v := range r.Metadata {
+		size += len(k) + len(v)
+	}
+	for _, d := range []Data{r.Key, r.Payload.Before, r.Payload.After} {
+		if d != nil {
+			size += d.Size()
+		}
+	}
+	return size
+}
+
 func (r Record) Map() map[string]interface{} {
 	var genericMetadata map[string]interface{}
 	if r.Metadata != nil {
@@ -147,3 +164,26 @@ func (r Record) Clone() Record {
 	}
 	return clone
 }
+
+// DeepClone returns a deep copy of the record. Unlike Clone it also copies
+// slices and typed maps (e.g. map[string]string) contained in StructuredData
+// and keeps the types of nested maps, so that the copy can be mutated without
+// affecting the original record.
+func (r Record) DeepClone() Record {
+	clone := Record{Position: r.Position, Operation: r.Operation, Metadata: r.Metadata}.Clone()
+	clone.Key = deepCloneData(r.Key)
+	clone.Payload.Before = deepCloneData(r.Payload.Before)
+	clone.Payload.After = deepCloneData(r.Payload.After)
+	return clone
+}
+
+func deepCloneData(d Data) Data {
+	switch d := d.(type) {
+	case nil:
+		return nil
+	case StructuredData:
+		return DeepCloneValue(d).(StructuredData)
+	default:
+		return d.Clone()
+	}
+}
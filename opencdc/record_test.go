@@ -18,7 +18,6 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/matryer/is"
 )
 
@@ -72,11 +71,76 @@ func TestRecord_Clone(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			is := is.New(t)
 			got := tc.input.Clone()
-			is.Equal(cmp.Diff(tc.input, got, cmpopts.IgnoreUnexported(Record{})), "")
+			is.Equal(cmp.Diff(tc.input, got, exactRecord), "")
 		})
 	}
 }
 
+func TestRecord_DeepClone(t *testing.T) {
+	is := is.New(t)
+
+	in := Record{
+		Position: Position("standing"),
+		Metadata: Metadata{"foo": "bar"},
+		Key:      RawData("padlock-key"),
+		Payload: Change{
+			After: StructuredData{
+				"nested": map[string]any{"tags": []any{"a", map[string]any{"b": 1}}},
+				"data":   StructuredData{"c": []any{1, 2}},
+			},
+		},
+	}
+	got := in.DeepClone()
+	is.Equal(cmp.Diff(in, got, exactRecord), "")
+
+	// mutating the clone doesn't affect the original
+	after := got.Payload.After.(StructuredData)
+	tags := after["nested"].(map[string]any)["tags"].([]any)
+	tags[0] = "x"
+	tags[1].(map[string]any)["b"] = 2
+	after["data"].(StructuredData)["c"].([]any)[0] = 3
+
+	is.Equal(in.Payload.After, StructuredData{
+		"nested": map[string]any{"tags": []any{"a", map[string]any{"b": 1}}},
+		"data":   StructuredData{"c": []any{1, 2}},
+	})
+}
+
+func TestRecord_DeepClone_TypedContainers(t *testing.T) {
+	is := is.New(t)
+
+	in := Record{
+		Payload: Change{
+			After: StructuredData{
+				"user":   map[string]string{"ssn": "123-45-6789"},
+				"tags":   []string{"a", "b"},
+				"nested": map[string][]int{"ids": {1, 2}},
+				"array":  [1]map[string]int{{"a": 1}},
+				"bytes":  []byte("foo"),
+				"nil":    map[string]string(nil),
+			},
+		},
+	}
+	got := in.DeepClone()
+	is.Equal(got.Payload.After, in.Payload.After)
+
+	after := got.Payload.After.(StructuredData)
+	after["user"].(map[string]string)["ssn"] = "****"
+	after["tags"].([]string)[0] = "x"
+	after["nested"].(map[string][]int)["ids"][0] = 3
+	after["array"].([1]map[string]int)[0]["a"] = 2
+	after["bytes"].([]byte)[0] = 'x'
+
+	is.Equal(in.Payload.After, StructuredData{
+		"user":   map[string]string{"ssn": "123-45-6789"},
+		"tags":   []string{"a", "b"},
+		"nested": map[string][]int{"ids": {1, 2}},
+		"array":  [1]map[string]int{{"a": 1}},
+		"bytes":  []byte("foo"),
+		"nil":    map[string]string(nil),
+	})
+}
+
 func TestRecord_Bytes(t *testing.T) {
 	is := is.New(t)
 
@@ -0,0 +1,291 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// RedactStrategy defines how a sensitive value is redacted.
+type RedactStrategy int
+
+const (
+	// RedactDrop removes the field.
+	RedactDrop RedactStrategy = iota + 1
+	// RedactMask replaces the value with a fixed mask.
+	RedactMask
+	// RedactKeepLast replaces all but the last N characters of the value with
+	// '*'. Values with N characters or fewer are masked completely.
+	RedactKeepLast
+	// RedactHMAC replaces the value with the hex encoded HMAC-SHA256 of the
+	// value. Equal values produce equal tokens, which allows joining redacted
+	// records without revealing the values.
+	RedactHMAC
+)
+
+// DefaultRedactMask is the mask used by RedactMask if no mask is configured.
+const DefaultRedactMask = "****"
+
+// RedactRule selects fields and defines how they are redacted. A rule selects
+// fields either by Field or by Pattern. Fields can only be selected in
+// StructuredData, RawData in the key or payloads is left unchanged unless
+// RawData is set, in which case it is redacted as a whole.
+type RedactRule struct {
+	// Field is a path to the field relative to the data, e.g. ".email" or
+	// ".customer.address.street". It is applied to the record key and both
+	// payloads.
+	Field string
+	// Pattern selects fields by name. Fields at any depth whose name matches
+	// the pattern are redacted.
+	Pattern *regexp.Regexp
+
+	// Strategy defines how the selected fields are redacted.
+	Strategy RedactStrategy
+	// Mask is the value used by RedactMask. Defaults to DefaultRedactMask.
+	Mask string
+	// KeepLast is the number of trailing characters kept by RedactKeepLast.
+	KeepLast int
+	// HMACKey is the secret key used by RedactHMAC.
+	HMACKey []byte
+	// RawData controls whether the rule also applies to RawData in the key
+	// and payloads. Since fields in RawData can't be addressed, the whole
+	// data is redacted using Strategy (RedactDrop replaces it with nil).
+	// If false, RawData is not redacted by this rule.
+	RawData bool
+}
+
+// Redactor removes or obfuscates sensitive values in records, e.g. before
+// writing them to logs or a dead-letter queue.
+type Redactor struct {
+	rules []redactRule
+}
+
+type redactRule struct {
+	RedactRule
+	paths []Path // paths for the key and payloads, if Field is set
+}
+
+// NewRedactor validates the rules and returns a redactor that applies them
+// in order. It returns ErrInvalidRedactRule if a rule is misconfigured.
+func NewRedactor(rules ...RedactRule) (*Redactor, error) {
+	var errs []error
+	compiled := make([]redactRule, len(rules))
+	for i, rule := range rules {
+		compiled[i].RedactRule = rule
+		if (rule.Field == "") == (rule.Pattern == nil) {
+			errs = append(errs, fmt.Errorf("rule %d: exactly one of field or pattern needs to be set", i))
+			continue
+		}
+		switch rule.Strategy {
+		case RedactDrop, RedactMask:
+		case RedactKeepLast:
+			if rule.KeepLast < 0 {
+				errs = append(errs, fmt.Errorf("rule %d: keep last can't be negative", i))
+			}
+		case RedactHMAC:
+			if len(rule.HMACKey) == 0 {
+				errs = append(errs, fmt.Errorf("rule %d: HMAC key is required", i))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("rule %d: unknown strategy %d", i, rule.Strategy))
+		}
+		if rule.Field != "" {
+			for _, root := range []string{".Key", ".Payload.Before", ".Payload.After"} {
+				p, err := ParsePath(root + rule.Field)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("rule %d: %w", i, err))
+					break
+				}
+				compiled[i].paths = append(compiled[i].paths, p)
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRedactRule, err)
+	}
+	return &Redactor{rules: compiled}, nil
+}
+
+// Redact returns a clone of the record with the rules applied to the key and
+// both payloads. The original record is not modified. RawData is only
+// redacted by rules with RawData set, otherwise it is returned unchanged.
+func (rd *Redactor) Redact(r Record) (Record, error) {
+	r = r.DeepClone()
+	for _, rule := range rd.rules {
+		if rule.RawData {
+			for _, d := range []*Data{&r.Key, &r.Payload.Before, &r.Payload.After} {
+				if raw, ok := (*d).(RawData); ok {
+					*d = rule.redactRaw(raw)
+				}
+			}
+		}
+		if rule.Pattern != nil {
+			for _, d := range []Data{r.Key, r.Payload.Before, r.Payload.After} {
+				if sd, ok := d.(StructuredData); ok {
+					if err := rule.redactMatching(sd); err != nil {
+						return Record{}, fmt.Errorf("failed to redact record: %w", err)
+					}
+				}
+			}
+			continue
+		}
+		for _, p := range rule.paths {
+			v, err := p.Get(r)
+			if err != nil {
+				if errors.Is(err, ErrFieldNotFound) || errors.Is(err, ErrNotStructuredData) {
+					continue
+				}
+				return Record{}, fmt.Errorf("failed to redact record: %w", err)
+			}
+			if rule.Strategy == RedactDrop {
+				err = p.Delete(&r)
+			} else {
+				err = p.Set(&r, rule.redact(v))
+			}
+			if err != nil {
+				return Record{}, fmt.Errorf("failed to redact record: %w", err)
+			}
+		}
+	}
+	return r, nil
+}
+
+// redactMatching redacts all fields in v with a name that matches the pattern.
+// Maps and slices of any type are traversed. It returns an error if a matching
+// field is stored in a map that can't hold the redacted value (e.g. a
+// map[string]int), so that the field is never left unredacted.
+func (rule redactRule) redactMatching(v any) error {
+	switch v := v.(type) {
+	case StructuredData:
+		return rule.redactMatching(map[string]any(v))
+	case map[string]any:
+		for k, vv := range v {
+			if !rule.Pattern.MatchString(k) {
+				if err := rule.redactMatching(vv); err != nil {
+					return err
+				}
+				continue
+			}
+			if rule.Strategy == RedactDrop {
+				delete(v, k)
+			} else {
+				v[k] = rule.redact(vv)
+			}
+		}
+		return nil
+	case []any:
+		for _, vv := range v {
+			if err := rule.redactMatching(vv); err != nil {
+				return err
+			}
+		}
+		return nil
+	case nil, string, []byte:
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() { //nolint:exhaustive // other kinds can't contain fields
+	case reflect.Map:
+		iter := rv.MapRange()
+		for iter.Next() {
+			k, vv := iter.Key(), iter.Value()
+			if k.Kind() != reflect.String || !rule.Pattern.MatchString(k.String()) {
+				if err := rule.redactMatching(vv.Interface()); err != nil {
+					return err
+				}
+				continue
+			}
+			if rule.Strategy == RedactDrop {
+				rv.SetMapIndex(k, reflect.Value{})
+				continue
+			}
+			redacted := reflect.ValueOf(rule.redact(vv.Interface()))
+			if !redacted.IsValid() {
+				redacted = reflect.Zero(rv.Type().Elem())
+			}
+			if !redacted.Type().AssignableTo(rv.Type().Elem()) {
+				return fmt.Errorf("can't redact field %q: %w: can't store %T in %s",
+					k.String(), ErrInvalidFieldType, redacted.Interface(), rv.Type())
+			}
+			rv.SetMapIndex(k, redacted)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := rule.redactMatching(rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// redactRaw returns the redacted representation of raw data.
+func (rule redactRule) redactRaw(raw RawData) Data {
+	if rule.Strategy == RedactDrop {
+		return nil
+	}
+	return RawData(rule.redact([]byte(raw)).(string))
+}
+
+// redact returns the redacted representation of v. Nil values stay nil.
+func (rule redactRule) redact(v any) any {
+	if v == nil {
+		return nil
+	}
+	switch rule.Strategy { //nolint:exhaustive // drop is handled by the caller
+	case RedactMask:
+		if rule.Mask == "" {
+			return DefaultRedactMask
+		}
+		return rule.Mask
+	case RedactKeepLast:
+		s := redactString(v)
+		n := utf8.RuneCountInString(s)
+		if n <= rule.KeepLast {
+			return strings.Repeat("*", n)
+		}
+		runes := []rune(s)
+		return strings.Repeat("*", n-rule.KeepLast) + string(runes[n-rule.KeepLast:])
+	case RedactHMAC:
+		mac := hmac.New(sha256.New, rule.HMACKey)
+		mac.Write([]byte(redactString(v)))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	return v
+}
+
+// redactString returns the string representation of a value. Strings and
+// byte slices are returned as is, other values are canonically encoded.
+func redactString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+	var buf bytes.Buffer
+	writeCanonicalValue(&buf, v)
+	return buf.String()
+}
@@ -0,0 +1,230 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/matryer/is"
+)
+
+func TestRedactor_Redact(t *testing.T) {
+	is := is.New(t)
+
+	newRecord := func() Record {
+		return Record{
+			Operation: OperationUpdate,
+			Metadata:  Metadata{MetadataConduitDLQNackError: "boom"},
+			Key:       StructuredData{"email": "foo@example.com"},
+			Payload: Change{
+				Before: StructuredData{
+					"email":   "foo@example.com",
+					"ssn":     "123-45-6789",
+					"card":    4111111111111111,
+					"profile": map[string]any{"password": "secret", "name": "foo"},
+					"contacts": []any{
+						map[string]any{"phone_number": "555-1234"},
+					},
+				},
+				After: RawData("raw"),
+			},
+		}
+	}
+
+	hmacKey := []byte("key")
+	mac := hmac.New(sha256.New, hmacKey)
+	mac.Write([]byte("foo@example.com"))
+	wantToken := hex.EncodeToString(mac.Sum(nil))
+
+	rd, err := NewRedactor(
+		RedactRule{Field: ".email", Strategy: RedactHMAC, HMACKey: hmacKey},
+		RedactRule{Field: ".ssn", Strategy: RedactKeepLast, KeepLast: 4},
+		RedactRule{Field: ".card", Strategy: RedactKeepLast, KeepLast: 4},
+		RedactRule{Pattern: regexp.MustCompile(`(?i)password`), Strategy: RedactDrop},
+		RedactRule{Pattern: regexp.MustCompile(`phone`), Strategy: RedactMask},
+		RedactRule{Field: ".missing", Strategy: RedactMask, Mask: "x"},
+	)
+	is.NoErr(err)
+
+	in := newRecord()
+	got, err := rd.Redact(in)
+	is.NoErr(err)
+
+	want := Record{
+		Operation: OperationUpdate,
+		Metadata:  Metadata{MetadataConduitDLQNackError: "boom"},
+		Key:       StructuredData{"email": wantToken},
+		Payload: Change{
+			Before: StructuredData{
+				"email":   wantToken,
+				"ssn":     "*******6789",
+				"card":    "************1111",
+				"profile": map[string]any{"name": "foo"},
+				"contacts": []any{
+					map[string]any{"phone_number": DefaultRedactMask},
+				},
+			},
+			After: RawData("raw"),
+		},
+	}
+	is.Equal(cmp.Diff(want, got, exactRecord), "")
+	// the original record is untouched
+	is.Equal(cmp.Diff(newRecord(), in, exactRecord), "")
+}
+
+func TestRedactor_TypedContainers(t *testing.T) {
+	is := is.New(t)
+
+	newRecord := func() Record {
+		return Record{
+			Payload: Change{
+				After: StructuredData{
+					"user": map[string]string{"ssn": "123-45-6789", "password": "secret", "name": "foo"},
+					"accounts": []map[string]any{
+						{"password": "hunter2", "id": 1},
+					},
+					"tags": map[string][]string{"roles": {"admin"}},
+				},
+			},
+		}
+	}
+
+	rd, err := NewRedactor(
+		RedactRule{Field: ".user.ssn", Strategy: RedactKeepLast, KeepLast: 4},
+		RedactRule{Pattern: regexp.MustCompile(`password`), Strategy: RedactMask},
+	)
+	is.NoErr(err)
+
+	in := newRecord()
+	got, err := rd.Redact(in)
+	is.NoErr(err)
+	is.Equal(got.Payload.After, StructuredData{
+		"user": map[string]string{"ssn": "*******6789", "password": DefaultRedactMask, "name": "foo"},
+		"accounts": []map[string]any{
+			{"password": DefaultRedactMask, "id": 1},
+		},
+		"tags": map[string][]string{"roles": {"admin"}},
+	})
+	// the original record is untouched
+	is.Equal(in, newRecord())
+}
+
+func TestRedactor_TypedContainers_Error(t *testing.T) {
+	is := is.New(t)
+
+	rd, err := NewRedactor(RedactRule{Pattern: regexp.MustCompile(`pin`), Strategy: RedactMask})
+	is.NoErr(err)
+
+	// the mask can't be stored in a map[string]int, the value must not leak
+	_, err = rd.Redact(Record{Key: StructuredData{"user": map[string]int{"pin": 1234}}})
+	is.True(errors.Is(err, ErrInvalidFieldType))
+
+	// dropping works with any map type
+	rd, err = NewRedactor(RedactRule{Pattern: regexp.MustCompile(`pin`), Strategy: RedactDrop})
+	is.NoErr(err)
+	got, err := rd.Redact(Record{Key: StructuredData{"user": map[string]int{"pin": 1234, "id": 1}}})
+	is.NoErr(err)
+	is.Equal(got.Key, StructuredData{"user": map[string]int{"id": 1}})
+}
+
+func TestRedactor_KeepLastShortValue(t *testing.T) {
+	is := is.New(t)
+	rd, err := NewRedactor(RedactRule{Field: ".pin", Strategy: RedactKeepLast, KeepLast: 4})
+	is.NoErr(err)
+	got, err := rd.Redact(Record{Key: StructuredData{"pin": "1234"}})
+	is.NoErr(err)
+	is.Equal(got.Key, StructuredData{"pin": "****"})
+}
+
+func TestRedactor_RawData(t *testing.T) {
+	in := Record{
+		Key: RawData("secret-key"),
+		Payload: Change{
+			Before: StructuredData{"email": "foo@example.com"},
+			After:  RawData("email=foo@example.com"),
+		},
+	}
+
+	testCases := []struct {
+		name string
+		rule RedactRule
+		want Record
+	}{{
+		name: "skipped by default",
+		rule: RedactRule{Field: ".email", Strategy: RedactMask},
+		want: Record{
+			Key: RawData("secret-key"),
+			Payload: Change{
+				Before: StructuredData{"email": DefaultRedactMask},
+				After:  RawData("email=foo@example.com"),
+			},
+		},
+	}, {
+		name: "mask",
+		rule: RedactRule{Field: ".email", Strategy: RedactMask, RawData: true},
+		want: Record{
+			Key: RawData(DefaultRedactMask),
+			Payload: Change{
+				Before: StructuredData{"email": DefaultRedactMask},
+				After:  RawData(DefaultRedactMask),
+			},
+		},
+	}, {
+		name: "drop",
+		rule: RedactRule{Pattern: regexp.MustCompile("email"), Strategy: RedactDrop, RawData: true},
+		want: Record{
+			Payload: Change{
+				Before: StructuredData{},
+			},
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			rd, err := NewRedactor(tc.rule)
+			is.NoErr(err)
+			got, err := rd.Redact(in)
+			is.NoErr(err)
+			is.Equal(cmp.Diff(tc.want, got, exactRecord), "")
+		})
+	}
+}
+
+func TestNewRedactor_Error(t *testing.T) {
+	testCases := []struct {
+		name string
+		rule RedactRule
+	}{
+		{name: "no selector", rule: RedactRule{Strategy: RedactDrop}},
+		{name: "both selectors", rule: RedactRule{Field: ".foo", Pattern: regexp.MustCompile("foo"), Strategy: RedactDrop}},
+		{name: "invalid field", rule: RedactRule{Field: "foo[", Strategy: RedactDrop}},
+		{name: "missing HMAC key", rule: RedactRule{Field: ".foo", Strategy: RedactHMAC}},
+		{name: "unknown strategy", rule: RedactRule{Field: ".foo"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			_, err := NewRedactor(tc.rule)
+			is.True(errors.Is(err, ErrInvalidRedactRule))
+		})
+	}
+}
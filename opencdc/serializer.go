@@ -15,10 +15,19 @@
 package opencdc
 
 import (
+	"bufio"
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 
+	opencdcv1 "github.com/conduitio/conduit-commons/proto/opencdc/v1"
 	"github.com/goccy/go-json"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
 )
 
 // RecordSerializer is a type that can serialize a record to bytes. It's used in
@@ -27,6 +36,12 @@ type RecordSerializer interface {
 	Serialize(Record) ([]byte, error)
 }
 
+// RecordDeserializer is a type that can deserialize bytes into a record. It's
+// the counterpart of RecordSerializer.
+type RecordDeserializer interface {
+	Deserialize([]byte) (Record, error)
+}
+
 // JSONSerializer is a RecordSerializer that serializes records to JSON using
 // the configured options.
 type JSONSerializer JSONMarshalOptions
@@ -45,3 +60,353 @@ func (s JSONSerializer) Serialize(r Record) ([]byte, error) {
 	}
 	return bytes, nil
 }
+
+// JSONDeserializer is a RecordDeserializer that parses records serialized to
+// JSON using the configured options.
+type JSONDeserializer JSONUnmarshalOptions
+
+func (d JSONDeserializer) Deserialize(b []byte) (Record, error) {
+	var r Record
+	err := r.unmarshalJSON(b, (*JSONUnmarshalOptions)(&d))
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to deserialize record from JSON: %w", err)
+	}
+	return r, nil
+}
+
+// ProtoSerializer is a RecordSerializer that serializes records to the binary
+// protobuf format defined in proto/opencdc/v1. StructuredData is converted to
+// a structpb.Struct, which can only represent numbers as doubles. To round-trip
+// values exactly, integers, float32 values, json.Number values and byte slices
+// are stored as tagged structs (e.g. int64(42) is stored as {"$int64":"42"}) and map keys
+// starting with "$" are escaped by doubling the "$". ProtoDeserializer and
+// ProtoDecoder restore the original values. Nested maps are deserialized as
+// map[string]any and slices as []any.
+type ProtoSerializer struct{}
+
+func (ProtoSerializer) Serialize(r Record) ([]byte, error) {
+	var protoRecord opencdcv1.Record
+	err := protoTypedRecord(r).ToProto(&protoRecord)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert record to proto: %w", err)
+	}
+	bytes, err := proto.Marshal(&protoRecord)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize record to proto: %w", err)
+	}
+	return bytes, nil
+}
+
+// ProtoDeserializer is a RecordDeserializer that parses records serialized by
+// ProtoSerializer.
+type ProtoDeserializer struct{}
+
+func (ProtoDeserializer) Deserialize(b []byte) (Record, error) {
+	var protoRecord opencdcv1.Record
+	err := proto.Unmarshal(b, &protoRecord)
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to deserialize record from proto: %w", err)
+	}
+	var r Record
+	err = r.FromProto(&protoRecord)
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to convert record from proto: %w", err)
+	}
+	err = protoUntypeRecord(&r)
+	if err != nil {
+		return Record{}, fmt.Errorf("failed to convert record from proto: %w", err)
+	}
+	return r, nil
+}
+
+// ProtoEncoder writes records to an output stream in the binary protobuf
+// format. Each record is prefixed with its size encoded as a varint, which
+// allows many records to be written to the same stream and read back using
+// ProtoDecoder.
+type ProtoEncoder struct {
+	w           io.Writer
+	protoRecord opencdcv1.Record
+}
+
+// NewProtoEncoder returns a new encoder that writes to w.
+func NewProtoEncoder(w io.Writer) *ProtoEncoder {
+	return &ProtoEncoder{w: w}
+}
+
+// Encode writes the size-delimited protobuf encoding of r to the stream.
+func (e *ProtoEncoder) Encode(r Record) error {
+	err := protoTypedRecord(r).ToProto(&e.protoRecord)
+	if err != nil {
+		return fmt.Errorf("failed to convert record to proto: %w", err)
+	}
+	_, err = protodelim.MarshalTo(e.w, &e.protoRecord)
+	if err != nil {
+		return fmt.Errorf("failed to write record: %w", err)
+	}
+	return nil
+}
+
+// ProtoDecoder reads size-delimited records written by ProtoEncoder from an
+// input stream.
+type ProtoDecoder struct {
+	// MaxSize is the maximum size of a single encoded record in bytes. Decoding
+	// a larger record returns an error. A zero MaxSize defaults to 4 MiB,
+	// setting it to -1 disables the limit.
+	MaxSize int64
+
+	r           protodelim.Reader
+	protoRecord opencdcv1.Record
+}
+
+// NewProtoDecoder returns a new decoder that reads from r. If r does not
+// implement io.ByteReader, it is wrapped in a bufio.Reader, in which case the
+// decoder may read data from r beyond the records it returns.
+func NewProtoDecoder(r io.Reader) *ProtoDecoder {
+	br, ok := r.(protodelim.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &ProtoDecoder{r: br}
+}
+
+// Decode reads the next record from the stream and stores it in r. At the end
+// of the stream Decode returns io.EOF.
+func (d *ProtoDecoder) Decode(r *Record) error {
+	d.protoRecord.Reset()
+	err := protodelim.UnmarshalOptions{MaxSize: d.MaxSize}.UnmarshalFrom(d.r, &d.protoRecord)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.EOF
+		}
+		return fmt.Errorf("failed to read record: %w", err)
+	}
+	err = r.FromProto(&d.protoRecord)
+	if err == nil {
+		err = protoUntypeRecord(r)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to convert record from proto: %w", err)
+	}
+	return nil
+}
+
+// Tags used by ProtoSerializer to store values that structpb can't represent
+// exactly.
+const (
+	protoTagPrefix  = "$"
+	protoTagInt     = "$int"
+	protoTagInt8    = "$int8"
+	protoTagInt16   = "$int16"
+	protoTagInt32   = "$int32"
+	protoTagInt64   = "$int64"
+	protoTagUint    = "$uint"
+	protoTagUint8   = "$uint8"
+	protoTagUint16  = "$uint16"
+	protoTagUint32  = "$uint32"
+	protoTagUint64  = "$uint64"
+	protoTagFloat32 = "$float32"
+	protoTagNumber  = "$number"
+	protoTagBytes   = "$bytes"
+)
+
+// protoTypedRecord returns a shallow copy of the record where structured data
+// is replaced with its tagged representation (see ProtoSerializer).
+func protoTypedRecord(r Record) Record {
+	r.Key = protoTypedData(r.Key)
+	r.Payload.Before = protoTypedData(r.Payload.Before)
+	r.Payload.After = protoTypedData(r.Payload.After)
+	return r
+}
+
+func protoTypedData(d Data) Data {
+	sd, ok := d.(StructuredData)
+	if !ok {
+		return d
+	}
+	return StructuredData(protoTypedMap(sd))
+}
+
+func protoTypedMap(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		if strings.HasPrefix(k, protoTagPrefix) {
+			k = protoTagPrefix + k
+		}
+		out[k] = protoTypedValue(v)
+	}
+	return out
+}
+
+func protoTypedValue(v any) any {
+	tagged := func(tag, val string) map[string]any {
+		return map[string]any{tag: val}
+	}
+	switch v := v.(type) {
+	case int:
+		return tagged(protoTagInt, strconv.FormatInt(int64(v), 10))
+	case int8:
+		return tagged(protoTagInt8, strconv.FormatInt(int64(v), 10))
+	case int16:
+		return tagged(protoTagInt16, strconv.FormatInt(int64(v), 10))
+	case int32:
+		return tagged(protoTagInt32, strconv.FormatInt(int64(v), 10))
+	case int64:
+		return tagged(protoTagInt64, strconv.FormatInt(v, 10))
+	case uint:
+		return tagged(protoTagUint, strconv.FormatUint(uint64(v), 10))
+	case uint8:
+		return tagged(protoTagUint8, strconv.FormatUint(uint64(v), 10))
+	case uint16:
+		return tagged(protoTagUint16, strconv.FormatUint(uint64(v), 10))
+	case uint32:
+		return tagged(protoTagUint32, strconv.FormatUint(uint64(v), 10))
+	case uint64:
+		return tagged(protoTagUint64, strconv.FormatUint(v, 10))
+	case float32:
+		return tagged(protoTagFloat32, strconv.FormatFloat(float64(v), 'g', -1, 32))
+	case json.Number:
+		return tagged(protoTagNumber, v.String())
+	case []byte:
+		return tagged(protoTagBytes, base64.StdEncoding.EncodeToString(v))
+	case StructuredData:
+		return protoTypedMap(v)
+	case map[string]any:
+		return protoTypedMap(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, vv := range v {
+			out[i] = protoTypedValue(vv)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+// protoUntypeRecord replaces the tagged values in the structured data of the
+// record with the original values.
+func protoUntypeRecord(r *Record) error {
+	var err error
+	if r.Key, err = protoUntypeData(r.Key); err != nil {
+		return fmt.Errorf("key: %w", err)
+	}
+	if r.Payload.Before, err = protoUntypeData(r.Payload.Before); err != nil {
+		return fmt.Errorf("payload.before: %w", err)
+	}
+	if r.Payload.After, err = protoUntypeData(r.Payload.After); err != nil {
+		return fmt.Errorf("payload.after: %w", err)
+	}
+	return nil
+}
+
+func protoUntypeData(d Data) (Data, error) {
+	sd, ok := d.(StructuredData)
+	if !ok {
+		return d, nil
+	}
+	m, err := protoUntypeMap(sd)
+	if err != nil {
+		return nil, err
+	}
+	return StructuredData(m), nil
+}
+
+func protoUntypeMap(m map[string]any) (map[string]any, error) {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		uv, err := protoUntypeValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		out[strings.TrimPrefix(k, protoTagPrefix)] = uv
+	}
+	return out, nil
+}
+
+func protoUntypeValue(v any) (any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		if len(v) == 1 {
+			for tag, val := range v {
+				if strings.HasPrefix(tag, protoTagPrefix) && !strings.HasPrefix(tag, protoTagPrefix+protoTagPrefix) {
+					s, ok := val.(string)
+					if !ok {
+						return nil, fmt.Errorf("invalid value for %s: %v", tag, val)
+					}
+					return protoUntypeTagged(tag, s)
+				}
+			}
+		}
+		return protoUntypeMap(v)
+	case []any:
+		for i, vv := range v {
+			uv, err := protoUntypeValue(vv)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			v[i] = uv
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+func protoUntypeTagged(tag, s string) (any, error) {
+	var (
+		v   any
+		err error
+	)
+	switch tag {
+	case protoTagInt:
+		var i int64
+		i, err = strconv.ParseInt(s, 10, strconv.IntSize)
+		v = int(i)
+	case protoTagInt8:
+		var i int64
+		i, err = strconv.ParseInt(s, 10, 8)
+		v = int8(i)
+	case protoTagInt16:
+		var i int64
+		i, err = strconv.ParseInt(s, 10, 16)
+		v = int16(i)
+	case protoTagInt32:
+		var i int64
+		i, err = strconv.ParseInt(s, 10, 32)
+		v = int32(i)
+	case protoTagInt64:
+		v, err = strconv.ParseInt(s, 10, 64)
+	case protoTagUint:
+		var u uint64
+		u, err = strconv.ParseUint(s, 10, strconv.IntSize)
+		v = uint(u)
+	case protoTagUint8:
+		var u uint64
+		u, err = strconv.ParseUint(s, 10, 8)
+		v = uint8(u)
+	case protoTagUint16:
+		var u uint64
+		u, err = strconv.ParseUint(s, 10, 16)
+		v = uint16(u)
+	case protoTagUint32:
+		var u uint64
+		u, err = strconv.ParseUint(s, 10, 32)
+		v = uint32(u)
+	case protoTagUint64:
+		v, err = strconv.ParseUint(s, 10, 64)
+	case protoTagFloat32:
+		var f float64
+		f, err = strconv.ParseFloat(s, 32)
+		v = float32(f)
+	case protoTagNumber:
+		v = json.Number(s)
+	case protoTagBytes:
+		v, err = base64.StdEncoding.DecodeString(s)
+	default:
+		return nil, fmt.Errorf("unknown type tag %s", tag)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for %s: %w", tag, err)
+	}
+	return v, nil
+}
@@ -15,9 +15,14 @@
 package opencdc
 
 import (
+	"bytes"
+	"io"
+	"math"
 	"testing"
 
+	"github.com/goccy/go-json"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/matryer/is"
 )
 
@@ -67,3 +72,188 @@ func TestJSONSerializer(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONDeserializer(t *testing.T) {
+	in := []byte(`{"position":"c3RhbmRpbmc=","operation":"update","metadata":{"foo":"bar"},"key":"padlock-key","payload":{"before":null,"after":{"big":9007199254740993,"float":1.5,"nested":{"list":[1,2.5]}}}}`)
+
+	testCases := []struct {
+		name         string
+		deserializer JSONDeserializer
+		wantAfter    StructuredData
+	}{{
+		name:         "float64",
+		deserializer: JSONDeserializer{RawDataAsString: true},
+		wantAfter: StructuredData{
+			"big":    float64(9007199254740992), // precision lost
+			"float":  1.5,
+			"nested": map[string]any{"list": []any{1.0, 2.5}},
+		},
+	}, {
+		name:         "json.Number",
+		deserializer: JSONDeserializer{RawDataAsString: true, NumberDecoding: JSONNumberDecodingNumber},
+		wantAfter: StructuredData{
+			"big":    json.Number("9007199254740993"),
+			"float":  json.Number("1.5"),
+			"nested": map[string]any{"list": []any{json.Number("1"), json.Number("2.5")}},
+		},
+	}, {
+		name:         "int64",
+		deserializer: JSONDeserializer{RawDataAsString: true, NumberDecoding: JSONNumberDecodingInt64},
+		wantAfter: StructuredData{
+			"big":    int64(9007199254740993),
+			"float":  1.5,
+			"nested": map[string]any{"list": []any{int64(1), 2.5}},
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			want := Record{
+				Position:  Position("standing"),
+				Operation: OperationUpdate,
+				Metadata:  Metadata{"foo": "bar"},
+				Key:       RawData("padlock-key"),
+				Payload:   Change{After: tc.wantAfter},
+			}
+			got, err := tc.deserializer.Deserialize(in)
+			is.NoErr(err)
+			is.Equal(cmp.Diff(want, got, exactRecord), "")
+		})
+	}
+}
+
+func TestProtoSerializer(t *testing.T) {
+	is := is.New(t)
+	rec := Record{
+		Position:  Position("standing"),
+		Operation: OperationUpdate,
+		Metadata:  Metadata{"foo": "bar"},
+		Key:       RawData("padlock-key"),
+		Payload: Change{
+			Before: RawData("yellow"),
+			After: StructuredData{
+				"bool":    true,
+				"float64": 1.2,
+				"string":  "orange",
+				"nested": map[string]any{
+					"int": 2.0,
+				},
+			},
+		},
+	}
+
+	rec.SetSerializer(ProtoSerializer{})
+	b := rec.Bytes()
+
+	got, err := ProtoDeserializer{}.Deserialize(b)
+	is.NoErr(err)
+	is.Equal(cmp.Diff(rec, got, exactRecord), "")
+}
+
+func TestProtoSerializer_Types(t *testing.T) {
+	is := is.New(t)
+	rec := Record{
+		Key: StructuredData{"id": int64(1 << 60)},
+		Payload: Change{
+			After: StructuredData{
+				"int":     42,
+				"int8":    int8(-8),
+				"int16":   int16(16),
+				"int32":   int32(32),
+				"int64":   int64(1 << 60),
+				"uint":    uint(1),
+				"uint8":   uint8(8),
+				"uint16":  uint16(16),
+				"uint32":  uint32(32),
+				"uint64":  uint64(math.MaxUint64),
+				"float32": float32(1.2),
+				"float64": 1.5,
+				"number":  json.Number("9007199254740993"),
+				"bytes":   []byte("foo"),
+				"$bytes":  "Zm9v",
+				"$$tag":   map[string]any{"$int": "1"},
+				"nested":  map[string]any{"list": []any{int32(1), 2.5, []byte("bar")}},
+			},
+		},
+	}
+
+	b, err := ProtoSerializer{}.Serialize(rec)
+	is.NoErr(err)
+	got, err := ProtoDeserializer{}.Deserialize(b)
+	is.NoErr(err)
+	is.Equal(cmp.Diff(rec, got, exactRecord), "")
+}
+
+func TestProtoSerializer_JSONNumbers(t *testing.T) {
+	is := is.New(t)
+
+	// numbers decoded from JSON as json.Number survive the round trip
+	in := []byte(`{"position":"cG9z","operation":"create","metadata":null,"key":null,"payload":{"before":null,"after":{"big":9007199254740993,"float":1.5}}}`)
+	rec, err := JSONDeserializer{NumberDecoding: JSONNumberDecodingNumber}.Deserialize(in)
+	is.NoErr(err)
+
+	b, err := ProtoSerializer{}.Serialize(rec)
+	is.NoErr(err)
+	got, err := ProtoDeserializer{}.Deserialize(b)
+	is.NoErr(err)
+	is.Equal(got.Payload.After, StructuredData{
+		"big":   json.Number("9007199254740993"),
+		"float": json.Number("1.5"),
+	})
+}
+
+func TestProtoDeserializer_Invalid(t *testing.T) {
+	is := is.New(t)
+	_, err := ProtoDeserializer{}.Deserialize([]byte("not a proto record"))
+	is.True(err != nil)
+}
+
+func TestProtoEncoder_Decoder(t *testing.T) {
+	is := is.New(t)
+	records := []Record{
+		{
+			Position:  Position("pos-1"),
+			Operation: OperationCreate,
+			Metadata:  Metadata{"foo": "bar"},
+			Key:       RawData("key-1"),
+			Payload:   Change{After: StructuredData{"id": 1}},
+		},
+		{
+			Position:  Position("pos-2"),
+			Operation: OperationDelete,
+			Key:       StructuredData{"id": int64(2)},
+			Payload:   Change{Before: RawData("before")},
+		},
+		{},
+	}
+
+	var buf bytes.Buffer
+	enc := NewProtoEncoder(&buf)
+	for _, r := range records {
+		is.NoErr(enc.Encode(r))
+	}
+
+	dec := NewProtoDecoder(bytes.NewReader(buf.Bytes()))
+	for _, want := range records {
+		var got Record
+		is.NoErr(dec.Decode(&got))
+		is.Equal(cmp.Diff(want, got, exactRecord, cmpopts.EquateEmpty()), "")
+	}
+
+	var r Record
+	is.Equal(dec.Decode(&r), io.EOF)
+}
+
+func TestProtoDecoder_Truncated(t *testing.T) {
+	is := is.New(t)
+
+	var buf bytes.Buffer
+	is.NoErr(NewProtoEncoder(&buf).Encode(Record{Position: Position("foo")}))
+
+	dec := NewProtoDecoder(bytes.NewReader(buf.Bytes()[:buf.Len()-1]))
+	var r Record
+	err := dec.Decode(&r)
+	is.True(err != nil)
+	is.True(err != io.EOF) //nolint:errorlint // we want to make sure it's not a clean EOF
+}
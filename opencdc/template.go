@@ -0,0 +1,153 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"text/template"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+// TemplateSerializer is a RecordSerializer that renders records using a Go
+// text/template. The template is executed with the Record as its data, so
+// fields can be accessed directly (e.g. {{ .Payload.After }}).
+//
+// On top of the builtin template functions, the following functions are
+// available:
+//   - base64: encodes a string, byte slice or Data as a base64 string.
+//   - raw: returns the bytes of a Position or Data as a string, so RawData is
+//     written as is and StructuredData as JSON (e.g. {{ raw .Key }}). Note
+//     that {{ .Key }} on its own prints RawData as a list of bytes.
+//   - toJSON: encodes any value (including Data and Record) as JSON.
+//   - meta: returns the value of a metadata field or an empty string if the
+//     field does not exist (e.g. {{ meta "opencdc.collection" .Metadata }}).
+//   - formatTime: formats a time using the supplied layout. The time can be a
+//     time.Time, a Unix timestamp in nanoseconds as an integer or string (the
+//     format used in metadata), e.g.
+//     {{ formatTime "2006-01-02" (meta "opencdc.createdAt" .Metadata) }}.
+type TemplateSerializer struct {
+	tmpl *template.Template
+}
+
+// NewTemplateSerializer parses the template text and returns a serializer that
+// renders records using the template.
+func NewTemplateSerializer(text string) (*TemplateSerializer, error) {
+	tmpl, err := template.New("record").
+		Option("missingkey=zero").
+		Funcs(templateFuncs).
+		Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return &TemplateSerializer{tmpl: tmpl}, nil
+}
+
+func (s *TemplateSerializer) Serialize(r Record) ([]byte, error) {
+	var buf bytes.Buffer
+	err := s.tmpl.Execute(&buf, r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+var templateFuncs = template.FuncMap{
+	"base64":     templateBase64,
+	"raw":        templateRaw,
+	"toJSON":     templateToJSON,
+	"meta":       templateMeta,
+	"formatTime": templateFormatTime,
+}
+
+func templateBase64(v any) (string, error) {
+	var b []byte
+	switch v := v.(type) {
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	case Position:
+		b = v
+	case Data:
+		if v != nil {
+			b = v.Bytes()
+		}
+	case nil:
+	default:
+		return "", fmt.Errorf("base64: unsupported type %T", v)
+	}
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func templateRaw(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case Position:
+		return string(v), nil
+	case Data:
+		if v == nil {
+			return "", nil
+		}
+		return string(v.Bytes()), nil
+	case nil:
+		return "", nil
+	default:
+		return "", fmt.Errorf("raw: unsupported type %T", v)
+	}
+}
+
+func templateToJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("toJSON: %w", err)
+	}
+	return string(b), nil
+}
+
+func templateMeta(key string, m Metadata) string {
+	return m[key]
+}
+
+func templateFormatTime(layout string, v any) (string, error) {
+	var t time.Time
+	switch v := v.(type) {
+	case time.Time:
+		t = v
+	case string:
+		if v == "" {
+			return "", nil
+		}
+		nanos, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("formatTime: failed to parse %q as a unix timestamp in nanoseconds: %w", v, err)
+		}
+		t = time.Unix(0, nanos)
+	case int:
+		t = time.Unix(0, int64(v))
+	case int64:
+		t = time.Unix(0, v)
+	default:
+		return "", fmt.Errorf("formatTime: unsupported type %T", v)
+	}
+	return t.UTC().Format(layout), nil
+}
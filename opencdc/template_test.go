@@ -0,0 +1,91 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+)
+
+func TestTemplateSerializer(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	rec := Record{
+		Position:  Position("standing"),
+		Operation: OperationUpdate,
+		Metadata: Metadata{
+			MetadataCollection: "users",
+			MetadataCreatedAt:  "1709296200000000000",
+		},
+		Key: RawData("padlock-key"),
+		Payload: Change{
+			After: StructuredData{"id": 1, "name": "foo"},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		template string
+		want     string
+	}{{
+		name:     "payload after",
+		template: `{{ toJSON .Payload.After }}`,
+		want:     `{"id":1,"name":"foo"}`,
+	}, {
+		name:     "custom envelope",
+		template: `{"table":{{ toJSON (meta "opencdc.collection" .Metadata) }},"op":"{{ .Operation }}","data":{{ toJSON .Payload.After }}}`,
+		want:     `{"table":"users","op":"update","data":{"id":1,"name":"foo"}}`,
+	}, {
+		name:     "base64",
+		template: `{{ base64 .Key }} {{ base64 .Position }} {{ base64 "foo" }}`,
+		want:     `cGFkbG9jay1rZXk= c3RhbmRpbmc= Zm9v`,
+	}, {
+		name:     "raw",
+		template: `{{ raw .Key }} {{ raw .Position }} {{ raw .Payload.After }} [{{ raw .Payload.Before }}]`,
+		want:     `padlock-key standing {"id":1,"name":"foo"} []`,
+	}, {
+		name:     "missing metadata",
+		template: `[{{ meta "foo" .Metadata }}]`,
+		want:     `[]`,
+	}, {
+		name:     "format time from metadata",
+		template: `{{ formatTime "2006-01-02T15:04:05Z07:00" (meta "opencdc.createdAt" .Metadata) }}`,
+		want:     createdAt.Format(time.RFC3339),
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			s, err := NewTemplateSerializer(tc.template)
+			is.NoErr(err)
+			got, err := s.Serialize(rec)
+			is.NoErr(err)
+			is.Equal(string(got), tc.want)
+		})
+	}
+}
+
+func TestTemplateSerializer_Errors(t *testing.T) {
+	is := is.New(t)
+
+	_, err := NewTemplateSerializer(`{{ .Payload.After`)
+	is.True(err != nil)
+
+	s, err := NewTemplateSerializer(`{{ formatTime "2006" (meta "foo" .Metadata | printf "x%s") }}`)
+	is.NoErr(err)
+	_, err = s.Serialize(Record{})
+	is.True(err != nil)
+}
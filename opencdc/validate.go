@@ -0,0 +1,191 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ValidationError describes a single violation found while validating a
+// record.
+type ValidationError struct {
+	// Field is the path of the invalid field (e.g. .Payload.After or
+	// .Metadata["opencdc.version"]).
+	Field string
+	// Err describes the violation.
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks that the record is well-formed and returns all violations.
+// It checks that:
+//   - the operation is known,
+//   - Payload.After is populated for creates and snapshots,
+//   - Payload.After is not populated for deletes,
+//   - known metadata fields containing typed values (timestamps, schema
+//     versions, file fields) can be parsed.
+//
+// Every violation is reported as a *ValidationError, multiple violations are
+// joined using errors.Join. If the record is valid, the function returns nil.
+func (r Record) Validate() error {
+	return errors.Join(r.validate(false)...)
+}
+
+// ValidateStrict is like Validate, but additionally checks that:
+//   - the position is populated,
+//   - the metadata contains the current OpenCDC version,
+//   - Payload.After is populated for updates,
+//   - Payload.Before is not populated for creates and snapshots,
+//   - schema subjects and versions are either both present or both missing,
+//   - chunked files contain the chunk index and count.
+func (r Record) ValidateStrict() error {
+	return errors.Join(r.validate(true)...)
+}
+
+func (r Record) validate(strict bool) []error {
+	var errs []error
+	addErr := func(field string, err error) {
+		errs = append(errs, &ValidationError{Field: field, Err: err})
+	}
+
+	if strict && len(r.Position) == 0 {
+		addErr(".Position", ErrMissingValue)
+	}
+
+	switch r.Operation {
+	case OperationCreate, OperationSnapshot:
+		if r.Payload.After == nil {
+			addErr(".Payload.After", fmt.Errorf("%w for operation %s", ErrMissingValue, r.Operation))
+		}
+		if strict && r.Payload.Before != nil {
+			addErr(".Payload.Before", fmt.Errorf("%w for operation %s", ErrUnexpectedValue, r.Operation))
+		}
+	case OperationUpdate:
+		if strict && r.Payload.After == nil {
+			addErr(".Payload.After", fmt.Errorf("%w for operation %s", ErrMissingValue, r.Operation))
+		}
+	case OperationDelete:
+		if r.Payload.After != nil {
+			addErr(".Payload.After", fmt.Errorf("%w for operation %s", ErrUnexpectedValue, r.Operation))
+		}
+	default:
+		addErr(".Operation", fmt.Errorf("operation %q: %w", r.Operation, ErrUnknownOperation))
+	}
+
+	return append(errs, r.Metadata.validate(strict)...)
+}
+
+func (m Metadata) validate(strict bool) []error {
+	var errs []error
+	metadataField := func(key string) string {
+		return ".Metadata" + pathField{name: key}.String()
+	}
+	addErr := func(key string, err error) {
+		errs = append(errs, &ValidationError{Field: metadataField(key), Err: err})
+	}
+	// check calls get and reports an error if the value can't be parsed. It
+	// returns true if the value is present and valid.
+	check := func(key string, get func() error) bool {
+		err := get()
+		switch {
+		case err == nil:
+			return true
+		case errors.Is(err, ErrMetadataFieldNotFound):
+			return false
+		default:
+			addErr(key, fmt.Errorf("%w: %w", ErrInvalidMetadataValue, err))
+			return false
+		}
+	}
+	has := func(key string) bool {
+		return m[key] != ""
+	}
+
+	if strict {
+		version, err := m.GetOpenCDCVersion()
+		switch {
+		case err != nil:
+			addErr(MetadataOpenCDCVersion, ErrMetadataFieldNotFound)
+		case version != OpenCDCVersion:
+			addErr(MetadataOpenCDCVersion, fmt.Errorf("%w: expected %q, got %q", ErrInvalidMetadataValue, OpenCDCVersion, version))
+		}
+	}
+
+	check(MetadataCreatedAt, func() error { _, err := m.GetCreatedAt(); return err })
+	check(MetadataReadAt, func() error { _, err := m.GetReadAt(); return err })
+	check(MetadataKeySchemaVersion, func() error { _, err := m.GetKeySchemaVersion(); return err })
+	check(MetadataPayloadSchemaVersion, func() error { _, err := m.GetPayloadSchemaVersion(); return err })
+	check(MetadataFileSize, func() error { _, err := m.GetFileSize(); return err })
+
+	var chunked bool
+	check(MetadataFileChunked, func() error {
+		var err error
+		chunked, err = m.GetFileChunked()
+		return err
+	})
+	var chunkIndex, chunkCount int
+	hasIndex := check(MetadataFileChunkIndex, func() error {
+		var err error
+		chunkIndex, err = m.GetFileChunkIndex()
+		return err
+	})
+	hasCount := check(MetadataFileChunkCount, func() error {
+		var err error
+		chunkCount, err = m.GetFileChunkCount()
+		return err
+	})
+	if hasCount && chunkCount < 1 {
+		addErr(MetadataFileChunkCount, fmt.Errorf("%w: chunk count %d is not positive", ErrInvalidMetadataValue, chunkCount))
+		hasCount = false
+	}
+	if hasIndex && (chunkIndex < 1 || (hasCount && chunkIndex > chunkCount)) {
+		addErr(MetadataFileChunkIndex, fmt.Errorf("%w: chunk index %d is out of range", ErrInvalidMetadataValue, chunkIndex))
+	}
+
+	if strict {
+		if chunked {
+			if !has(MetadataFileChunkIndex) {
+				addErr(MetadataFileChunkIndex, ErrMetadataFieldNotFound)
+			}
+			if !has(MetadataFileChunkCount) {
+				addErr(MetadataFileChunkCount, ErrMetadataFieldNotFound)
+			}
+		}
+		if has(MetadataKeySchemaSubject) != has(MetadataKeySchemaVersion) {
+			if has(MetadataKeySchemaSubject) {
+				addErr(MetadataKeySchemaVersion, ErrMetadataFieldNotFound)
+			} else {
+				addErr(MetadataKeySchemaSubject, ErrMetadataFieldNotFound)
+			}
+		}
+		if has(MetadataPayloadSchemaSubject) != has(MetadataPayloadSchemaVersion) {
+			if has(MetadataPayloadSchemaSubject) {
+				addErr(MetadataPayloadSchemaVersion, ErrMetadataFieldNotFound)
+			} else {
+				addErr(MetadataPayloadSchemaSubject, ErrMetadataFieldNotFound)
+			}
+		}
+	}
+
+	return errs
+}
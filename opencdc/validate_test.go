@@ -0,0 +1,200 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opencdc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+// validationErrors returns the field and the sentinel error of all validation
+// errors contained in err.
+func validationErrors(t *testing.T, err error) map[string]error {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) {
+		t.Fatalf("expected joined errors, got %T", err)
+	}
+	sentinels := []error{
+		ErrMissingValue, ErrUnexpectedValue, ErrInvalidMetadataValue,
+		ErrUnknownOperation, ErrMetadataFieldNotFound,
+	}
+	out := make(map[string]error)
+	for _, err := range joined.Unwrap() {
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Fatalf("expected *ValidationError, got %T", err)
+		}
+		for _, s := range sentinels {
+			if errors.Is(verr, s) {
+				out[verr.Field] = s
+			}
+		}
+	}
+	return out
+}
+
+func TestRecord_Validate(t *testing.T) {
+	valid := func() Record {
+		return Record{
+			Position:  Position("foo"),
+			Operation: OperationCreate,
+			Metadata:  Metadata{MetadataOpenCDCVersion: OpenCDCVersion},
+			Key:       RawData("key"),
+			Payload:   Change{After: StructuredData{"foo": "bar"}},
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		record     func() Record
+		wantLax    map[string]error
+		wantStrict map[string]error
+	}{{
+		name:   "valid",
+		record: valid,
+	}, {
+		name: "unknown operation",
+		record: func() Record {
+			r := valid()
+			r.Operation = Operation(9)
+			return r
+		},
+		wantLax:    map[string]error{".Operation": ErrUnknownOperation},
+		wantStrict: map[string]error{".Operation": ErrUnknownOperation},
+	}, {
+		name: "create without after",
+		record: func() Record {
+			r := valid()
+			r.Payload.After = nil
+			return r
+		},
+		wantLax:    map[string]error{".Payload.After": ErrMissingValue},
+		wantStrict: map[string]error{".Payload.After": ErrMissingValue},
+	}, {
+		name: "delete with after",
+		record: func() Record {
+			r := valid()
+			r.Operation = OperationDelete
+			return r
+		},
+		wantLax:    map[string]error{".Payload.After": ErrUnexpectedValue},
+		wantStrict: map[string]error{".Payload.After": ErrUnexpectedValue},
+	}, {
+		name: "update without after",
+		record: func() Record {
+			r := valid()
+			r.Operation = OperationUpdate
+			r.Payload = Change{Before: StructuredData{}}
+			return r
+		},
+		wantStrict: map[string]error{".Payload.After": ErrMissingValue},
+	}, {
+		name: "snapshot with before",
+		record: func() Record {
+			r := valid()
+			r.Operation = OperationSnapshot
+			r.Payload.Before = StructuredData{}
+			return r
+		},
+		wantStrict: map[string]error{".Payload.Before": ErrUnexpectedValue},
+	}, {
+		name: "missing position and version",
+		record: func() Record {
+			r := valid()
+			r.Position = nil
+			r.Metadata = nil
+			return r
+		},
+		wantStrict: map[string]error{
+			".Position":                    ErrMissingValue,
+			`.Metadata["opencdc.version"]`: ErrMetadataFieldNotFound,
+		},
+	}, {
+		name: "invalid metadata values",
+		record: func() Record {
+			r := valid()
+			r.Metadata[MetadataOpenCDCVersion] = "v0"
+			r.Metadata[MetadataCreatedAt] = "yesterday"
+			r.Metadata[MetadataKeySchemaVersion] = "one"
+			r.Metadata[MetadataFileChunked] = "maybe"
+			r.Metadata[MetadataFileChunkIndex] = "1a"
+			return r
+		},
+		wantLax: map[string]error{
+			`.Metadata["opencdc.createdAt"]`:          ErrInvalidMetadataValue,
+			`.Metadata["opencdc.key.schema.version"]`: ErrInvalidMetadataValue,
+			`.Metadata["opencdc.file.chunked"]`:       ErrInvalidMetadataValue,
+			`.Metadata["opencdc.file.chunk.index"]`:   ErrInvalidMetadataValue,
+		},
+		wantStrict: map[string]error{
+			`.Metadata["opencdc.version"]`:            ErrInvalidMetadataValue,
+			`.Metadata["opencdc.createdAt"]`:          ErrInvalidMetadataValue,
+			`.Metadata["opencdc.key.schema.version"]`: ErrInvalidMetadataValue,
+			`.Metadata["opencdc.file.chunked"]`:       ErrInvalidMetadataValue,
+			`.Metadata["opencdc.file.chunk.index"]`:   ErrInvalidMetadataValue,
+			`.Metadata["opencdc.key.schema.subject"]`: ErrMetadataFieldNotFound,
+		},
+	}, {
+		name: "chunk index out of range",
+		record: func() Record {
+			r := valid()
+			r.Metadata.SetFileChunked(true)
+			r.Metadata.SetFileChunkIndex(3)
+			r.Metadata.SetFileChunkCount(2)
+			return r
+		},
+		wantLax:    map[string]error{`.Metadata["opencdc.file.chunk.index"]`: ErrInvalidMetadataValue},
+		wantStrict: map[string]error{`.Metadata["opencdc.file.chunk.index"]`: ErrInvalidMetadataValue},
+	}, {
+		name: "chunked without index and count",
+		record: func() Record {
+			r := valid()
+			r.Metadata.SetFileChunked(true)
+			r.Metadata.SetPayloadSchemaSubject("foo")
+			r.Metadata.SetPayloadSchemaVersion(1)
+			return r
+		},
+		wantStrict: map[string]error{
+			`.Metadata["opencdc.file.chunk.index"]`: ErrMetadataFieldNotFound,
+			`.Metadata["opencdc.file.chunk.count"]`: ErrMetadataFieldNotFound,
+		},
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			r := tc.record()
+			is.Equal(validationErrors(t, r.Validate()), tc.wantLax)
+			is.Equal(validationErrors(t, r.ValidateStrict()), tc.wantStrict)
+		})
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	is := is.New(t)
+	err := Record{Operation: OperationDelete, Payload: Change{After: RawData("foo")}}.Validate()
+	is.Equal(err.Error(), ".Payload.After: unexpected value for operation delete")
+
+	var verr *ValidationError
+	is.True(errors.As(err, &verr))
+	is.Equal(verr.Field, ".Payload.After")
+	is.True(errors.Is(err, ErrUnexpectedValue))
+}
@@ -0,0 +1,201 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rabin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+)
+
+const (
+	// WindowSize is the number of bytes the rolling hash of the Chunker is
+	// calculated over.
+	WindowSize = 64
+
+	// DefaultMinSize is the default minimum chunk size.
+	DefaultMinSize = 512 << 10 // 512 KiB
+	// DefaultAvgSize is the default average chunk size.
+	DefaultAvgSize = 1 << 20 // 1 MiB
+	// DefaultMaxSize is the default maximum chunk size.
+	DefaultMaxSize = 8 << 20 // 8 MiB
+)
+
+// ErrInvalidChunkSize is returned when creating a Chunker with invalid size
+// options.
+var ErrInvalidChunkSize = errors.New("invalid chunk size")
+
+// windowOutTable contains the contribution of a byte leaving the window to the
+// rolling hash, i.e. the hash of the byte followed by WindowSize zero bytes.
+var windowOutTable = newWindowOutTable()
+
+func newWindowOutTable() [256]uint64 {
+	var table [256]uint64
+	zeros := make([]byte, WindowSize)
+	for i := 0; i < 256; i++ {
+		d := update(0, []byte{byte(i)})
+		table[i] = uint64(update(d, zeros))
+	}
+	return table
+}
+
+// ChunkerOptions define the chunk sizes produced by Chunker.
+type ChunkerOptions struct {
+	// MinSize is the minimum size of a chunk. Only the last chunk can be
+	// smaller. Defaults to DefaultMinSize.
+	MinSize int
+	// AvgSize is the target average size of the content-defined part of a
+	// chunk, i.e. chunks are on average MinSize+AvgSize bytes long. It needs
+	// to be a power of two. Defaults to DefaultAvgSize.
+	AvgSize int
+	// MaxSize is the maximum size of a chunk. Defaults to DefaultMaxSize.
+	MaxSize int
+}
+
+// Chunk is a part of the content returned by Chunker.
+type Chunk struct {
+	// Offset is the position of the chunk in the content.
+	Offset int64
+	// Data is the content of the chunk. It is only valid until the next call
+	// to Chunker.Next.
+	Data []byte
+	// Fingerprint is the Rabin fingerprint of Data (see Bytes).
+	Fingerprint uint64
+}
+
+// Chunker splits content into chunks using content-defined chunking. A
+// rolling Rabin hash is calculated over a window of WindowSize bytes and a
+// chunk boundary is placed where the hash matches a pattern. Because
+// boundaries depend only on the content around them, an edit only changes the
+// chunks around the edit, while unchanged regions produce the same chunks
+// across versions of the content.
+type Chunker struct {
+	r    io.Reader
+	min  int
+	max  int
+	mask uint64
+
+	buf    []byte
+	start  int
+	end    int
+	eof    bool
+	offset int64
+}
+
+// NewChunker returns a chunker that reads content from r. It returns
+// ErrInvalidChunkSize if the options are invalid.
+func NewChunker(r io.Reader, options ChunkerOptions) (*Chunker, error) {
+	if options.MinSize == 0 {
+		options.MinSize = DefaultMinSize
+	}
+	if options.AvgSize == 0 {
+		options.AvgSize = DefaultAvgSize
+	}
+	if options.MaxSize == 0 {
+		options.MaxSize = DefaultMaxSize
+	}
+	switch {
+	case options.MinSize < 0:
+		return nil, fmt.Errorf("min size %d is negative: %w", options.MinSize, ErrInvalidChunkSize)
+	case options.AvgSize < 0 || bits.OnesCount(uint(options.AvgSize)) != 1:
+		return nil, fmt.Errorf("average size %d is not a power of two: %w", options.AvgSize, ErrInvalidChunkSize)
+	case options.MaxSize < options.MinSize || options.MaxSize < options.AvgSize:
+		return nil, fmt.Errorf("max size %d is smaller than min or average size: %w", options.MaxSize, ErrInvalidChunkSize)
+	}
+
+	return &Chunker{
+		r:    r,
+		min:  options.MinSize,
+		max:  options.MaxSize,
+		mask: uint64(options.AvgSize - 1), //nolint:gosec // checked above that it's positive
+		buf:  make([]byte, options.MaxSize),
+	}, nil
+}
+
+// Next returns the next chunk. After the last chunk Next returns io.EOF.
+func (c *Chunker) Next() (Chunk, error) {
+	err := c.fill()
+	if err != nil {
+		return Chunk{}, err
+	}
+	if c.start == c.end {
+		return Chunk{}, io.EOF
+	}
+
+	data := c.buf[c.start:c.end]
+	size := c.boundary(data)
+	data = data[:size]
+	c.start += size
+
+	chunk := Chunk{
+		Offset:      c.offset,
+		Data:        data,
+		Fingerprint: Bytes(data),
+	}
+	c.offset += int64(size)
+	return chunk, nil
+}
+
+// fill makes sure the buffer contains at least MaxSize bytes, unless the end
+// of the content was reached.
+func (c *Chunker) fill() error {
+	if c.eof || c.end-c.start >= c.max {
+		return nil
+	}
+	c.end = copy(c.buf, c.buf[c.start:c.end])
+	c.start = 0
+	for c.end < len(c.buf) {
+		n, err := c.r.Read(c.buf[c.end:])
+		c.end += n
+		if errors.Is(err, io.EOF) {
+			c.eof = true
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read content: %w", err)
+		}
+	}
+	return nil
+}
+
+// boundary returns the size of the chunk at the start of data.
+func (c *Chunker) boundary(data []byte) int {
+	if len(data) <= c.min {
+		return len(data)
+	}
+	if len(data) > c.max {
+		data = data[:c.max]
+	}
+
+	// The hash only depends on the last WindowSize bytes, so we can skip
+	// hashing bytes that are not part of the window at the minimum size.
+	start := c.min - WindowSize
+	if start < 0 {
+		start = 0
+	}
+	var h uint64
+	for i := start; i < len(data); i++ {
+		var out byte
+		if i-WindowSize >= start {
+			out = data[i-WindowSize]
+		}
+		h = (h >> 8) ^ rabinTable[byte(h)^data[i]] ^ windowOutTable[out]
+		if i+1 >= c.min && h&c.mask == 0 {
+			return i + 1
+		}
+	}
+	return len(data)
+}
@@ -0,0 +1,155 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rabin
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"testing"
+	"testing/iotest"
+
+	"github.com/matryer/is"
+)
+
+func chunks(t *testing.T, r io.Reader, options ChunkerOptions) []Chunk {
+	is := is.New(t)
+	c, err := NewChunker(r, options)
+	is.NoErr(err)
+
+	var got []Chunk
+	for {
+		chunk, err := c.Next()
+		if errors.Is(err, io.EOF) {
+			return got
+		}
+		is.NoErr(err)
+		chunk.Data = bytes.Clone(chunk.Data)
+		got = append(got, chunk)
+	}
+}
+
+func TestWindowOutTable(t *testing.T) {
+	is := is.New(t)
+
+	data := make([]byte, 1000)
+	rand.New(rand.NewSource(1)).Read(data)
+
+	// rolling the hash over the data produces the same hash as hashing the
+	// window directly
+	var h uint64
+	for i := range data {
+		var out byte
+		if i >= WindowSize {
+			out = data[i-WindowSize]
+		}
+		h = (h >> 8) ^ rabinTable[byte(h)^data[i]] ^ windowOutTable[out]
+		if i >= WindowSize {
+			want := uint64(update(0, data[i-WindowSize+1:i+1]))
+			is.Equal(h, want)
+		}
+	}
+}
+
+func TestChunker(t *testing.T) {
+	is := is.New(t)
+
+	data := make([]byte, 1<<20)
+	rand.New(rand.NewSource(1)).Read(data)
+	options := ChunkerOptions{MinSize: 2 << 10, AvgSize: 8 << 10, MaxSize: 32 << 10}
+
+	got := chunks(t, bytes.NewReader(data), options)
+	is.True(len(got) > 20)
+
+	var offset int64
+	var joined []byte
+	for i, c := range got {
+		is.Equal(c.Offset, offset)
+		is.Equal(c.Fingerprint, Bytes(c.Data))
+		is.True(len(c.Data) <= options.MaxSize)
+		if i < len(got)-1 {
+			is.True(len(c.Data) >= options.MinSize)
+		}
+		offset += int64(len(c.Data))
+		joined = append(joined, c.Data...)
+	}
+	is.Equal(joined, data)
+
+	// chunking is deterministic and independent of how the reader returns data
+	is.Equal(chunks(t, iotest.OneByteReader(bytes.NewReader(data)), options), got)
+}
+
+func TestChunker_Dedup(t *testing.T) {
+	is := is.New(t)
+
+	data := make([]byte, 1<<20)
+	rand.New(rand.NewSource(1)).Read(data)
+	options := ChunkerOptions{MinSize: 2 << 10, AvgSize: 8 << 10, MaxSize: 32 << 10}
+
+	// insert bytes in the middle of the content
+	edited := append(bytes.Clone(data[:500_000]), []byte("inserted content")...)
+	edited = append(edited, data[500_000:]...)
+
+	before := chunks(t, bytes.NewReader(data), options)
+	after := chunks(t, bytes.NewReader(edited), options)
+
+	fingerprints := make(map[uint64]bool)
+	for _, c := range before {
+		fingerprints[c.Fingerprint] = true
+	}
+	changed := 0
+	for _, c := range after {
+		if !fingerprints[c.Fingerprint] {
+			changed++
+		}
+	}
+	is.True(changed > 0)
+	is.True(changed <= 2) // only the chunks around the edit change
+}
+
+func TestNewChunker_InvalidOptions(t *testing.T) {
+	testCases := []ChunkerOptions{
+		{MinSize: -1},
+		{AvgSize: 1000},
+		{MinSize: 1024, AvgSize: 2048, MaxSize: 1500},
+	}
+	for _, options := range testCases {
+		is := is.New(t)
+		_, err := NewChunker(bytes.NewReader(nil), options)
+		is.True(errors.Is(err, ErrInvalidChunkSize))
+	}
+}
+
+func TestChunker_Empty(t *testing.T) {
+	is := is.New(t)
+	is.Equal(len(chunks(t, bytes.NewReader(nil), ChunkerOptions{})), 0)
+}
+
+func BenchmarkChunker(b *testing.B) {
+	data := make([]byte, 16<<20)
+	rand.New(rand.NewSource(1)).Read(data)
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c, _ := NewChunker(bytes.NewReader(data), ChunkerOptions{})
+		for {
+			_, err := c.Next()
+			if err != nil {
+				break
+			}
+		}
+	}
+}
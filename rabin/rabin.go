@@ -14,6 +14,8 @@
 
 // Package rabin provides a Rabin fingerprint hash.Hash64 implementation compatible
 // with the Avro spec: https://avro.apache.org/docs/1.8.2/spec.html#schema_fingerprints.
+// It also provides Chunker, which uses a rolling Rabin hash to split content
+// into content-defined chunks.
 package rabin
 
 import "hash"
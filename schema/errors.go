@@ -23,4 +23,15 @@ var (
 
 	// ErrUnsupportedType is returned when an unsupported type is encountered.
 	ErrUnsupportedType = errors.New("unsupported type")
+
+	// ErrSchemaNotFound is returned when a schema referenced by a record can
+	// not be resolved.
+	ErrSchemaNotFound = errors.New("schema not found")
+
+	// ErrInvalidWireFormat is returned when data is not framed in the
+	// Confluent wire format.
+	ErrInvalidWireFormat = errors.New("invalid wire format")
+	// ErrInvalidSchemaID is returned when a schema ID can not be represented
+	// in the Confluent wire format.
+	ErrInvalidSchemaID = errors.New("invalid schema ID")
 )
@@ -0,0 +1,30 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+// Lookup resolves a schema by its subject and version. It is usually backed by
+// a schema registry.
+type Lookup interface {
+	Get(subject string, version int) (Schema, error)
+}
+
+// LookupFunc is an adapter that allows the use of an ordinary function as a
+// Lookup.
+type LookupFunc func(subject string, version int) (Schema, error)
+
+// Get calls f(subject, version).
+func (f LookupFunc) Get(subject string, version int) (Schema, error) {
+	return f(subject, version)
+}
@@ -0,0 +1,137 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// RecordSerializer is an opencdc.RecordSerializer that encodes record data
+// using the schemas attached to the record (see AttachKeySchemaToRecord and
+// AttachPayloadSchemaToRecord). Schemas are resolved using Lookup.
+//
+// Serialize encodes Payload.After, SerializeKey encodes Key. If no schema is
+// attached, StructuredData is encoded as JSON. RawData is always returned
+// unchanged, as it is assumed to already be encoded.
+type RecordSerializer struct {
+	Lookup Lookup
+}
+
+var _ opencdc.RecordSerializer = RecordSerializer{}
+
+// Serialize encodes the record's Payload.After using the schema referenced in
+// the metadata fields opencdc.payload.schema.subject and
+// opencdc.payload.schema.version.
+func (s RecordSerializer) Serialize(r opencdc.Record) ([]byte, error) {
+	subject, err := r.Metadata.GetPayloadSchemaSubject()
+	if err != nil && !errors.Is(err, opencdc.ErrMetadataFieldNotFound) {
+		return nil, fmt.Errorf("failed to get payload schema subject: %w", err)
+	}
+	var version int
+	if subject != "" {
+		version, err = r.Metadata.GetPayloadSchemaVersion()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get payload schema version: %w", err)
+		}
+	}
+
+	out, err := s.encode(r.Payload.After, subject, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize payload: %w", err)
+	}
+	return out, nil
+}
+
+// SerializeKey encodes the record's Key using the schema referenced in the
+// metadata fields opencdc.key.schema.subject and opencdc.key.schema.version.
+func (s RecordSerializer) SerializeKey(r opencdc.Record) ([]byte, error) {
+	subject, err := r.Metadata.GetKeySchemaSubject()
+	if err != nil && !errors.Is(err, opencdc.ErrMetadataFieldNotFound) {
+		return nil, fmt.Errorf("failed to get key schema subject: %w", err)
+	}
+	var version int
+	if subject != "" {
+		version, err = r.Metadata.GetKeySchemaVersion()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key schema version: %w", err)
+		}
+	}
+
+	out, err := s.encode(r.Key, subject, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize key: %w", err)
+	}
+	return out, nil
+}
+
+func (s RecordSerializer) encode(d opencdc.Data, subject string, version int) ([]byte, error) {
+	sd, ok := d.(opencdc.StructuredData)
+	if !ok {
+		// RawData is already encoded, nil data stays nil.
+		if d == nil {
+			return nil, nil
+		}
+		return d.Bytes(), nil
+	}
+	if subject == "" {
+		// No schema attached, fall back to JSON.
+		return sd.Bytes(), nil
+	}
+
+	if s.Lookup == nil {
+		return nil, fmt.Errorf("schema %v:%v is attached to the record, but no lookup is configured: %w", subject, version, ErrSchemaNotFound)
+	}
+	sch, err := s.Lookup.Get(subject, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema %v:%v: %w", subject, version, err)
+	}
+
+	// The serde may mutate the value, so we encode a plain copy.
+	b, err := sch.Marshal(plainMap(sd))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal structured data with schema %v:%v: %w", subject, version, err)
+	}
+	return b, nil
+}
+
+// plainMap returns a deep copy of the structured data where all nested
+// StructuredData values are converted to map[string]any.
+func plainMap(sd opencdc.StructuredData) map[string]any {
+	out := make(map[string]any, len(sd))
+	for k, v := range sd {
+		out[k] = plainValue(v)
+	}
+	return out
+}
+
+func plainValue(v any) any {
+	switch v := v.(type) {
+	case opencdc.StructuredData:
+		return plainMap(v)
+	case map[string]any:
+		return plainMap(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, vv := range v {
+			out[i] = plainValue(vv)
+		}
+		return out
+	default:
+		return v
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/matryer/is"
+)
+
+func TestRecordSerializer(t *testing.T) {
+	is := is.New(t)
+
+	keySchema := Schema{
+		Subject: "key",
+		Version: 1,
+		ID:      1,
+		Type:    TypeAvro,
+		Bytes:   []byte(`{"type":"record","name":"key","fields":[{"name":"id","type":"long"}]}`),
+	}
+	payloadSchema := Schema{
+		Subject: "payload",
+		Version: 2,
+		ID:      2,
+		Type:    TypeAvro,
+		Bytes: []byte(`{"type":"record","name":"payload","fields":[
+			{"name":"name","type":"string"},
+			{"name":"address","type":{"type":"record","name":"address","fields":[{"name":"city","type":"string"}]}}
+		]}`),
+	}
+	lookup := LookupFunc(func(subject string, version int) (Schema, error) {
+		for _, s := range []Schema{keySchema, payloadSchema} {
+			if s.Subject == subject && s.Version == version {
+				return s, nil
+			}
+		}
+		return Schema{}, ErrSchemaNotFound
+	})
+
+	rec := opencdc.Record{
+		Metadata: opencdc.Metadata{},
+		Key:      opencdc.StructuredData{"id": int64(123)},
+		Payload: opencdc.Change{
+			After: opencdc.StructuredData{
+				"name":    "john",
+				"address": opencdc.StructuredData{"city": "Amsterdam"},
+			},
+		},
+	}
+	AttachKeySchemaToRecord(rec, keySchema)
+	AttachPayloadSchemaToRecord(rec, payloadSchema)
+
+	s := RecordSerializer{Lookup: lookup}
+
+	gotKey, err := s.SerializeKey(rec)
+	is.NoErr(err)
+	wantKey, err := keySchema.Marshal(map[string]any{"id": int64(123)})
+	is.NoErr(err)
+	is.Equal(gotKey, wantKey)
+
+	gotPayload, err := s.Serialize(rec)
+	is.NoErr(err)
+	var decoded map[string]any
+	is.NoErr(payloadSchema.Unmarshal(gotPayload, &decoded))
+	is.Equal(decoded, map[string]any{
+		"name":    "john",
+		"address": map[string]any{"city": "Amsterdam"},
+	})
+
+	// the serializer can be used through the record
+	rec.SetSerializer(s)
+	is.Equal(rec.Bytes(), gotPayload)
+}
+
+func TestRecordSerializer_NoSchema(t *testing.T) {
+	is := is.New(t)
+
+	rec := opencdc.Record{
+		Key:     opencdc.RawData("raw-key"),
+		Payload: opencdc.Change{After: opencdc.StructuredData{"foo": "bar"}},
+	}
+	s := RecordSerializer{}
+
+	gotKey, err := s.SerializeKey(rec)
+	is.NoErr(err)
+	is.Equal(gotKey, []byte("raw-key"))
+
+	gotPayload, err := s.Serialize(rec)
+	is.NoErr(err)
+	is.Equal(string(gotPayload), `{"foo":"bar"}`)
+}
+
+func TestRecordSerializer_SchemaNotFound(t *testing.T) {
+	is := is.New(t)
+
+	rec := opencdc.Record{
+		Metadata: opencdc.Metadata{},
+		Payload:  opencdc.Change{After: opencdc.StructuredData{"foo": "bar"}},
+	}
+	AttachPayloadSchemaToRecord(rec, Schema{Subject: "payload", Version: 1})
+
+	s := RecordSerializer{Lookup: LookupFunc(func(string, int) (Schema, error) {
+		return Schema{}, ErrSchemaNotFound
+	})}
+	_, err := s.Serialize(rec)
+	is.True(errors.Is(err, ErrSchemaNotFound))
+
+	_, err = RecordSerializer{}.Serialize(rec)
+	is.True(errors.Is(err, ErrSchemaNotFound))
+}
@@ -0,0 +1,100 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+const (
+	// wireFormatMagicByte is the first byte of data encoded in the Confluent
+	// wire format.
+	wireFormatMagicByte byte = 0
+	// wireFormatHeaderSize is the size of the Confluent wire format header,
+	// one magic byte followed by a 4-byte big-endian schema ID.
+	wireFormatHeaderSize = 5
+)
+
+// IDLookup resolves a schema by its ID. It is usually backed by a schema
+// registry.
+type IDLookup interface {
+	GetByID(id int) (Schema, error)
+}
+
+// IDLookupFunc is an adapter that allows the use of an ordinary function as an
+// IDLookup.
+type IDLookupFunc func(id int) (Schema, error)
+
+// GetByID calls f(id).
+func (f IDLookupFunc) GetByID(id int) (Schema, error) {
+	return f(id)
+}
+
+// MarshalWireFormat returns the encoded representation of v framed in the
+// Confluent wire format: a magic byte, followed by the 4-byte big-endian ID of
+// schema s, followed by the data encoded with s.
+func MarshalWireFormat(s Schema, v any) ([]byte, error) {
+	if s.ID < 0 || s.ID > math.MaxInt32 {
+		return nil, fmt.Errorf("schema ID %d out of range: %w", s.ID, ErrInvalidSchemaID)
+	}
+	body, err := s.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value with schema %v:%v (ID %d): %w", s.Subject, s.Version, s.ID, err)
+	}
+
+	out := make([]byte, wireFormatHeaderSize, wireFormatHeaderSize+len(body))
+	out[0] = wireFormatMagicByte
+	binary.BigEndian.PutUint32(out[1:], uint32(s.ID)) //nolint:gosec // range is checked above
+	return append(out, body...), nil
+}
+
+// UnmarshalWireFormat parses data framed in the Confluent wire format. The
+// schema ID contained in the header is resolved using lookup and the remaining
+// data is unmarshaled into the value pointed to by v. The resolved schema is
+// returned.
+func UnmarshalWireFormat(b []byte, lookup IDLookup, v any) (Schema, error) {
+	id, body, err := ParseWireFormat(b)
+	if err != nil {
+		return Schema{}, err
+	}
+	s, err := lookup.GetByID(id)
+	if err != nil {
+		return Schema{}, fmt.Errorf("failed to get schema with ID %d: %w", id, err)
+	}
+	err = s.Unmarshal(body, v)
+	if err != nil {
+		return Schema{}, fmt.Errorf("failed to unmarshal data with schema %v:%v (ID %d): %w", s.Subject, s.Version, id, err)
+	}
+	return s, nil
+}
+
+// ParseWireFormat splits data framed in the Confluent wire format into the
+// schema ID and the encoded body. It returns ErrInvalidWireFormat if the data
+// is too short or does not start with the magic byte.
+func ParseWireFormat(b []byte) (int, []byte, error) {
+	if len(b) < wireFormatHeaderSize {
+		return 0, nil, fmt.Errorf("expected at least %d bytes, got %d: %w", wireFormatHeaderSize, len(b), ErrInvalidWireFormat)
+	}
+	if b[0] != wireFormatMagicByte {
+		return 0, nil, fmt.Errorf("unknown magic byte %#x: %w", b[0], ErrInvalidWireFormat)
+	}
+	id := binary.BigEndian.Uint32(b[1:wireFormatHeaderSize])
+	if id > math.MaxInt32 {
+		return 0, nil, fmt.Errorf("schema ID %d out of range: %w", id, ErrInvalidSchemaID)
+	}
+	return int(id), b[wireFormatHeaderSize:], nil
+}
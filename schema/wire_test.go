@@ -0,0 +1,99 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+)
+
+func TestWireFormat(t *testing.T) {
+	is := is.New(t)
+
+	s := Schema{
+		Subject: "foo",
+		Version: 1,
+		ID:      258,
+		Type:    TypeAvro,
+		Bytes:   []byte(`{"type":"record","name":"foo","fields":[{"name":"id","type":"long"}]}`),
+	}
+	lookup := IDLookupFunc(func(id int) (Schema, error) {
+		if id != s.ID {
+			return Schema{}, ErrSchemaNotFound
+		}
+		return s, nil
+	})
+
+	b, err := MarshalWireFormat(s, map[string]any{"id": int64(7)})
+	is.NoErr(err)
+	is.Equal(b[:5], []byte{0, 0, 0, 1, 2}) // magic byte and schema ID
+
+	var got map[string]any
+	gotSchema, err := UnmarshalWireFormat(b, lookup, &got)
+	is.NoErr(err)
+	is.Equal(gotSchema, s)
+	is.Equal(got, map[string]any{"id": int64(7)})
+
+	_, err = UnmarshalWireFormat([]byte{0, 0, 0, 0, 1, 2}, lookup, &got)
+	is.True(errors.Is(err, ErrSchemaNotFound))
+}
+
+func TestParseWireFormat(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     []byte
+		wantID   int
+		wantBody []byte
+		wantErr  error
+	}{{
+		name:     "valid",
+		have:     []byte{0, 0, 0, 0, 42, 1, 2, 3},
+		wantID:   42,
+		wantBody: []byte{1, 2, 3},
+	}, {
+		name:     "empty body",
+		have:     []byte{0, 0, 0, 0, 42},
+		wantID:   42,
+		wantBody: []byte{},
+	}, {
+		name:    "too short",
+		have:    []byte{0, 0, 0},
+		wantErr: ErrInvalidWireFormat,
+	}, {
+		name:    "wrong magic byte",
+		have:    []byte{1, 0, 0, 0, 42},
+		wantErr: ErrInvalidWireFormat,
+	}, {
+		name:    "schema ID out of range",
+		have:    []byte{0, 0xff, 0xff, 0xff, 0xff},
+		wantErr: ErrInvalidSchemaID,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			id, body, err := ParseWireFormat(tc.have)
+			if tc.wantErr != nil {
+				is.True(errors.Is(err, tc.wantErr))
+				return
+			}
+			is.NoErr(err)
+			is.Equal(id, tc.wantID)
+			is.Equal(body, tc.wantBody)
+		})
+	}
+}
@@ -0,0 +1,334 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/config"
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/goccy/go-json"
+)
+
+// CastType is the type a value can be cast to using Cast.
+type CastType string
+
+const (
+	CastTypeString CastType = "string"
+	CastTypeInt    CastType = "int"
+	CastTypeFloat  CastType = "float"
+	CastTypeBool   CastType = "bool"
+)
+
+var builtinSpecs = []Spec{
+	{
+		Name:    "rename",
+		Summary: "Moves the value of a field to a new location, e.g. to rename a field or move it from the key to the payload.",
+		Parameters: config.Parameters{
+			"from": {Description: "Path of the field to move.", Type: config.ParameterTypeString, Validations: []config.Validation{config.ValidationRequired{}}},
+			"to":   {Description: "Path of the new location.", Type: config.ParameterTypeString, Validations: []config.Validation{config.ValidationRequired{}}},
+		},
+		New: func(cfg config.Config) (Transform, error) {
+			from, to, err := parseFromTo(cfg)
+			if err != nil {
+				return nil, err
+			}
+			return Rename(from, to), nil
+		},
+	},
+	{
+		Name:    "copy",
+		Summary: "Copies the value of a field to a new location.",
+		Parameters: config.Parameters{
+			"from": {Description: "Path of the field to copy.", Type: config.ParameterTypeString, Validations: []config.Validation{config.ValidationRequired{}}},
+			"to":   {Description: "Path of the new location.", Type: config.ParameterTypeString, Validations: []config.Validation{config.ValidationRequired{}}},
+		},
+		New: func(cfg config.Config) (Transform, error) {
+			from, to, err := parseFromTo(cfg)
+			if err != nil {
+				return nil, err
+			}
+			return Copy(from, to), nil
+		},
+	},
+	{
+		Name:    "drop",
+		Summary: "Removes fields from the record.",
+		Parameters: config.Parameters{
+			"fields": {Description: "Comma separated list of paths of the fields to remove.", Type: config.ParameterTypeString, Validations: []config.Validation{config.ValidationRequired{}}},
+		},
+		New: func(cfg config.Config) (Transform, error) {
+			var paths []opencdc.Path
+			for _, f := range strings.Split(cfg["fields"], ",") {
+				p, err := opencdc.ParsePath(strings.TrimSpace(f))
+				if err != nil {
+					return nil, err //nolint:wrapcheck // error already contains the path
+				}
+				paths = append(paths, p)
+			}
+			return Drop(paths...), nil
+		},
+	},
+	{
+		Name:    "metadata.set",
+		Summary: "Sets a metadata field to a static value.",
+		Parameters: config.Parameters{
+			"key":   {Description: "Metadata key to set.", Type: config.ParameterTypeString, Validations: []config.Validation{config.ValidationRequired{}}},
+			"value": {Description: "Value of the metadata field.", Type: config.ParameterTypeString},
+		},
+		New: func(cfg config.Config) (Transform, error) {
+			return SetMetadata(cfg["key"], cfg["value"]), nil
+		},
+	},
+	{
+		Name:    "cast",
+		Summary: "Converts the value of a field to another type.",
+		Parameters: config.Parameters{
+			"field": {Description: "Path of the field to convert.", Type: config.ParameterTypeString, Validations: []config.Validation{config.ValidationRequired{}}},
+			"to": {Description: "Type the value is converted to.", Type: config.ParameterTypeString, Validations: []config.Validation{
+				config.ValidationRequired{},
+				config.ValidationInclusion{List: []string{string(CastTypeString), string(CastTypeInt), string(CastTypeFloat), string(CastTypeBool)}},
+			}},
+		},
+		New: func(cfg config.Config) (Transform, error) {
+			p, err := opencdc.ParsePath(cfg["field"])
+			if err != nil {
+				return nil, err //nolint:wrapcheck // error already contains the path
+			}
+			return Cast(p, CastType(cfg["to"])), nil
+		},
+	},
+}
+
+func parseFromTo(cfg config.Config) (from, to opencdc.Path, err error) {
+	from, err = opencdc.ParsePath(cfg["from"])
+	if err != nil {
+		return opencdc.Path{}, opencdc.Path{}, err //nolint:wrapcheck // error already contains the path
+	}
+	to, err = opencdc.ParsePath(cfg["to"])
+	if err != nil {
+		return opencdc.Path{}, opencdc.Path{}, err //nolint:wrapcheck // error already contains the path
+	}
+	return from, to, nil
+}
+
+// Rename moves the value at path from to path to. If the value at path from
+// does not exist, the record is returned unchanged.
+func Rename(from, to opencdc.Path) Transform {
+	return func(r opencdc.Record) (opencdc.Record, error) {
+		if _, err := from.Get(r); err != nil {
+			if errors.Is(err, opencdc.ErrFieldNotFound) {
+				return r, nil
+			}
+			return opencdc.Record{}, err //nolint:wrapcheck // error already contains the path
+		}
+		r = r.DeepClone()
+		// read the value from the clone, so the output doesn't share nested
+		// maps and slices with the input
+		v, err := from.Get(r)
+		if err != nil {
+			return opencdc.Record{}, err //nolint:wrapcheck // error already contains the path
+		}
+		err = from.Delete(&r)
+		if err != nil {
+			return opencdc.Record{}, err //nolint:wrapcheck // error already contains the path
+		}
+		err = to.Set(&r, v)
+		if err != nil {
+			return opencdc.Record{}, err //nolint:wrapcheck // error already contains the path
+		}
+		return r, nil
+	}
+}
+
+// Copy copies the value at path from to path to. If the value at path from
+// does not exist, the record is returned unchanged.
+func Copy(from, to opencdc.Path) Transform {
+	return func(r opencdc.Record) (opencdc.Record, error) {
+		v, err := from.Get(r)
+		if err != nil {
+			if errors.Is(err, opencdc.ErrFieldNotFound) {
+				return r, nil
+			}
+			return opencdc.Record{}, err //nolint:wrapcheck // error already contains the path
+		}
+		r = r.DeepClone()
+		err = to.Set(&r, opencdc.DeepCloneValue(v))
+		if err != nil {
+			return opencdc.Record{}, err //nolint:wrapcheck // error already contains the path
+		}
+		return r, nil
+	}
+}
+
+// Drop removes the values at the supplied paths. Values that don't exist are
+// ignored.
+func Drop(paths ...opencdc.Path) Transform {
+	return func(r opencdc.Record) (opencdc.Record, error) {
+		r = r.DeepClone()
+		for _, p := range paths {
+			err := p.Delete(&r)
+			if err != nil {
+				return opencdc.Record{}, err //nolint:wrapcheck // error already contains the path
+			}
+		}
+		return r, nil
+	}
+}
+
+// SetMetadata sets the metadata field key to value.
+func SetMetadata(key, value string) Transform {
+	return func(r opencdc.Record) (opencdc.Record, error) {
+		r = r.DeepClone()
+		if r.Metadata == nil {
+			r.Metadata = opencdc.Metadata{}
+		}
+		r.Metadata[key] = value
+		return r, nil
+	}
+}
+
+// Cast converts the value at path p to type t. Integers are represented as
+// int64 and floats as float64. Nil values and values that don't exist are left
+// unchanged. It returns ErrInvalidCast if the value can't be converted.
+func Cast(p opencdc.Path, t CastType) Transform {
+	return func(r opencdc.Record) (opencdc.Record, error) {
+		v, err := p.Get(r)
+		if err != nil {
+			if errors.Is(err, opencdc.ErrFieldNotFound) {
+				return r, nil
+			}
+			return opencdc.Record{}, err //nolint:wrapcheck // error already contains the path
+		}
+		if v == nil {
+			return r, nil
+		}
+		v, err = cast(v, t)
+		if err != nil {
+			return opencdc.Record{}, fmt.Errorf("path %q: %w", p, err)
+		}
+		r = r.DeepClone()
+		err = p.Set(&r, v)
+		if err != nil {
+			return opencdc.Record{}, err //nolint:wrapcheck // error already contains the path
+		}
+		return r, nil
+	}
+}
+
+func cast(v any, t CastType) (any, error) {
+	if n, ok := v.(json.Number); ok {
+		v = n.String()
+	}
+	rv := reflect.ValueOf(v)
+
+	switch t {
+	case CastTypeString:
+		switch rv.Kind() { //nolint:exhaustive // other kinds can't be cast
+		case reflect.String:
+			return rv.String(), nil
+		case reflect.Bool:
+			return strconv.FormatBool(rv.Bool()), nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return strconv.FormatInt(rv.Int(), 10), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return strconv.FormatUint(rv.Uint(), 10), nil
+		case reflect.Float32:
+			return strconv.FormatFloat(rv.Float(), 'f', -1, 32), nil
+		case reflect.Float64:
+			return strconv.FormatFloat(rv.Float(), 'f', -1, 64), nil
+		case reflect.Slice:
+			if rv.Type().Elem().Kind() == reflect.Uint8 {
+				return string(rv.Bytes()), nil
+			}
+		}
+	case CastTypeInt:
+		switch rv.Kind() { //nolint:exhaustive // other kinds can't be cast
+		case reflect.String:
+			i, err := strconv.ParseInt(strings.TrimSpace(rv.String()), 10, 64)
+			if err == nil {
+				return i, nil
+			}
+			f, ferr := strconv.ParseFloat(strings.TrimSpace(rv.String()), 64)
+			if ferr != nil || f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return nil, fmt.Errorf("can't cast %q to %s: %w", rv.String(), t, ErrInvalidCast)
+			}
+			return int64(f), nil
+		case reflect.Bool:
+			if rv.Bool() {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return rv.Int(), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if rv.Uint() > math.MaxInt64 {
+				return nil, fmt.Errorf("can't cast %d to %s: %w", rv.Uint(), t, ErrInvalidCast)
+			}
+			return int64(rv.Uint()), nil
+		case reflect.Float32, reflect.Float64:
+			f := rv.Float()
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return nil, fmt.Errorf("can't cast %v to %s without losing precision: %w", f, t, ErrInvalidCast)
+			}
+			return int64(f), nil
+		}
+	case CastTypeFloat:
+		switch rv.Kind() { //nolint:exhaustive // other kinds can't be cast
+		case reflect.String:
+			f, err := strconv.ParseFloat(strings.TrimSpace(rv.String()), 64)
+			if err != nil {
+				return nil, fmt.Errorf("can't cast %q to %s: %w", rv.String(), t, ErrInvalidCast)
+			}
+			return f, nil
+		case reflect.Bool:
+			if rv.Bool() {
+				return 1.0, nil
+			}
+			return 0.0, nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return float64(rv.Int()), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return float64(rv.Uint()), nil
+		case reflect.Float32, reflect.Float64:
+			return rv.Float(), nil
+		}
+	case CastTypeBool:
+		switch rv.Kind() { //nolint:exhaustive // other kinds can't be cast
+		case reflect.String:
+			b, err := strconv.ParseBool(strings.TrimSpace(rv.String()))
+			if err != nil {
+				return nil, fmt.Errorf("can't cast %q to %s: %w", rv.String(), t, ErrInvalidCast)
+			}
+			return b, nil
+		case reflect.Bool:
+			return rv.Bool(), nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return rv.Int() != 0, nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return rv.Uint() != 0, nil
+		case reflect.Float32, reflect.Float64:
+			return rv.Float() != 0, nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown type %q: %w", t, ErrInvalidCast)
+	}
+	return nil, fmt.Errorf("can't cast value of type %T to %s: %w", v, t, ErrInvalidCast)
+}
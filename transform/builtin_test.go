@@ -0,0 +1,130 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/goccy/go-json"
+	"github.com/matryer/is"
+)
+
+func TestCast(t *testing.T) {
+	testCases := []struct {
+		in      any
+		typ     CastType
+		want    any
+		wantErr error
+	}{
+		{in: 42, typ: CastTypeString, want: "42"},
+		{in: 1.5, typ: CastTypeString, want: "1.5"},
+		{in: true, typ: CastTypeString, want: "true"},
+		{in: []byte("foo"), typ: CastTypeString, want: "foo"},
+		{in: "42", typ: CastTypeInt, want: int64(42)},
+		{in: "42.0", typ: CastTypeInt, want: int64(42)},
+		{in: 42.0, typ: CastTypeInt, want: int64(42)},
+		{in: json.Number("9007199254740993"), typ: CastTypeInt, want: int64(9007199254740993)},
+		{in: 42.5, typ: CastTypeInt, wantErr: ErrInvalidCast},
+		{in: "foo", typ: CastTypeInt, wantErr: ErrInvalidCast},
+		{in: "1.5", typ: CastTypeFloat, want: 1.5},
+		{in: int32(3), typ: CastTypeFloat, want: 3.0},
+		{in: "true", typ: CastTypeBool, want: true},
+		{in: 0, typ: CastTypeBool, want: false},
+		{in: map[string]any{}, typ: CastTypeString, wantErr: ErrInvalidCast},
+		{in: "foo", typ: CastType("foo"), wantErr: ErrInvalidCast},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.typ), func(t *testing.T) {
+			is := is.New(t)
+			in := opencdc.Record{Payload: opencdc.Change{After: opencdc.StructuredData{"v": tc.in}}}
+			got, err := Cast(opencdc.MustParsePath(".Payload.After.v"), tc.typ)(in)
+			if tc.wantErr != nil {
+				is.True(errors.Is(err, tc.wantErr))
+				return
+			}
+			is.NoErr(err)
+			is.Equal(got.Payload.After.(opencdc.StructuredData)["v"], tc.want)
+		})
+	}
+}
+
+func TestRename_Missing(t *testing.T) {
+	is := is.New(t)
+	in := testRecord()
+	got, err := Rename(opencdc.MustParsePath(".Payload.After.foo"), opencdc.MustParsePath(".Payload.After.bar"))(in)
+	is.NoErr(err)
+	_, err = opencdc.MustParsePath(".Payload.After.bar").Get(got)
+	is.True(errors.Is(err, opencdc.ErrFieldNotFound))
+}
+
+func TestRename_DoesNotShareValues(t *testing.T) {
+	is := is.New(t)
+	in := testRecord()
+	got, err := Rename(opencdc.MustParsePath(".Payload.After.items"), opencdc.MustParsePath(".Key.items"))(in)
+	is.NoErr(err)
+
+	err = opencdc.MustParsePath(".Key.items[0]").Set(&got, "z")
+	is.NoErr(err)
+	is.Equal(got.Key.(opencdc.StructuredData)["items"], []any{"z", "b"})
+	is.Equal(in.Payload.After.(opencdc.StructuredData)["items"], []any{"a", "b"})
+}
+
+func TestCopy_DoesNotShareValues(t *testing.T) {
+	is := is.New(t)
+	in := testRecord()
+	got, err := Copy(opencdc.MustParsePath(".Payload.After.items"), opencdc.MustParsePath(".Key.items"))(in)
+	is.NoErr(err)
+
+	err = opencdc.MustParsePath(".Key.items[0]").Set(&got, "z")
+	is.NoErr(err)
+	is.Equal(got.Payload.After.(opencdc.StructuredData)["items"], []any{"a", "b"})
+	is.Equal(in.Payload.After.(opencdc.StructuredData)["items"], []any{"a", "b"})
+}
+
+func TestTransforms_TypedMapsNotModified(t *testing.T) {
+	newRecord := func() opencdc.Record {
+		return opencdc.Record{
+			Payload: opencdc.Change{
+				After: opencdc.StructuredData{
+					"user": map[string]string{"a": "1", "b": "2"},
+					"tags": []string{"x", "y"},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name string
+		tr   Transform
+	}{
+		{name: "drop", tr: Drop(opencdc.MustParsePath(".Payload.After.user.a"))},
+		{name: "rename", tr: Rename(opencdc.MustParsePath(".Payload.After.user.a"), opencdc.MustParsePath(".Payload.After.user.c"))},
+		{name: "cast", tr: Cast(opencdc.MustParsePath(".Payload.After.user.b"), CastTypeString)},
+		{name: "copy", tr: Copy(opencdc.MustParsePath(".Payload.After.user.a"), opencdc.MustParsePath(".Payload.After.user.d"))},
+		{name: "cast slice element", tr: Cast(opencdc.MustParsePath(".Payload.After.tags[0]"), CastTypeString)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			in := newRecord()
+			_, err := tc.tr(in)
+			is.NoErr(err)
+			is.Equal(in, newRecord())
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import "errors"
+
+var (
+	// ErrUnknownTransform is returned when building a transform with a name
+	// that doesn't match any built-in transform.
+	ErrUnknownTransform = errors.New("unknown transform")
+	// ErrInvalidConfig is returned when a chain of transforms can't be built
+	// because the configuration is malformed.
+	ErrInvalidConfig = errors.New("invalid transform configuration")
+	// ErrInvalidCast is returned when a value can't be cast to the requested
+	// type.
+	ErrInvalidCast = errors.New("invalid cast")
+)
@@ -0,0 +1,152 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package transform provides composable transformations of OpenCDC records
+// (e.g. renaming fields, dropping fields, setting metadata). Transforms can be
+// created directly in code or built from a declarative configuration that is
+// validated against the parameters each transform declares.
+package transform
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/config"
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// Transform is a function that transforms a record. A transform is pure, it
+// does not mutate the input record and returns a new record instead.
+type Transform func(opencdc.Record) (opencdc.Record, error)
+
+// Chain returns a transform that applies the supplied transforms in order. If
+// a transform returns an error, the chain stops and returns the error.
+func Chain(transforms ...Transform) Transform {
+	return func(r opencdc.Record) (opencdc.Record, error) {
+		var err error
+		for i, t := range transforms {
+			r, err = t(r)
+			if err != nil {
+				return opencdc.Record{}, fmt.Errorf("transform %d: %w", i, err)
+			}
+		}
+		return r, nil
+	}
+}
+
+// Spec describes a transform that can be built from a configuration.
+type Spec struct {
+	// Name is the name used to reference the transform in a configuration.
+	Name string
+	// Summary is a short description of what the transform does.
+	Summary string
+	// Parameters describe the configuration parameters of the transform.
+	Parameters config.Parameters
+	// New creates the transform from a configuration that was already
+	// validated against Parameters.
+	New func(config.Config) (Transform, error)
+}
+
+// Build sanitizes the configuration, applies the default values, validates it
+// against the parameters of the spec and creates the transform.
+func (s Spec) Build(cfg config.Config) (Transform, error) {
+	c := make(config.Config, len(cfg))
+	for k, v := range cfg {
+		c[k] = v
+	}
+	c = c.Sanitize().ApplyDefaults(s.Parameters)
+	err := c.Validate(s.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration for transform %q: %w", s.Name, err)
+	}
+	t, err := s.New(c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transform %q: %w", s.Name, err)
+	}
+	return t, nil
+}
+
+// Specs returns the specifications of all built-in transforms, keyed by their
+// name.
+func Specs() map[string]Spec {
+	specs := make(map[string]Spec, len(builtinSpecs))
+	for _, s := range builtinSpecs {
+		specs[s.Name] = s
+	}
+	return specs
+}
+
+// New builds the built-in transform with the supplied name from the
+// configuration. It returns ErrUnknownTransform if no such transform exists.
+func New(name string, cfg config.Config) (Transform, error) {
+	for _, s := range builtinSpecs {
+		if s.Name == name {
+			return s.Build(cfg)
+		}
+	}
+	return nil, fmt.Errorf("%q: %w", name, ErrUnknownTransform)
+}
+
+// Build builds a chain of built-in transforms from a configuration. The keys
+// of each transform are prefixed with the index of the transform in the chain,
+// the parameter "type" contains the name of the transform. For example:
+//
+//	0.type: rename
+//	0.from: .Key.id
+//	0.to:   .Payload.After.id
+//	1.type: metadata.set
+//	1.key:  source
+//	1.value: postgres
+//
+// Transforms are applied in the order of their indices.
+func Build(cfg config.Config) (Transform, error) {
+	groups := make(map[int]config.Config)
+	for k, v := range cfg {
+		prefix, param, ok := strings.Cut(strings.TrimSpace(k), ".")
+		index, err := strconv.Atoi(prefix)
+		if !ok || err != nil || index < 0 {
+			return nil, fmt.Errorf("%q: expected key in the format <index>.<parameter>: %w", k, ErrInvalidConfig)
+		}
+		if groups[index] == nil {
+			groups[index] = config.Config{}
+		}
+		groups[index][param] = v
+	}
+
+	indices := make([]int, 0, len(groups))
+	for i := range groups {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+
+	transforms := make([]Transform, len(indices))
+	for i, index := range indices {
+		c := groups[index]
+		name := strings.TrimSpace(c[paramType])
+		if name == "" {
+			return nil, fmt.Errorf("%q: missing transform type: %w", strconv.Itoa(index)+"."+paramType, ErrInvalidConfig)
+		}
+		delete(c, paramType)
+		t, err := New(name, c)
+		if err != nil {
+			return nil, fmt.Errorf("transform %d: %w", index, err)
+		}
+		transforms[i] = t
+	}
+	return Chain(transforms...), nil
+}
+
+const paramType = "type"
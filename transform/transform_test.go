@@ -0,0 +1,182 @@
+// Copyright © 2026 Meroxa, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transform
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/config"
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/google/go-cmp/cmp"
+	"github.com/matryer/is"
+)
+
+// exactRecord is a cmp option that compares records field by field instead of
+// using opencdc.Record.Equal, so that the types of values are compared exactly.
+var exactRecord = cmp.Transformer("Fields", func(r opencdc.Record) []any {
+	return []any{r.Position, r.Operation, r.Metadata, r.Key, r.Payload}
+})
+
+func testRecord() opencdc.Record {
+	return opencdc.Record{
+		Position:  opencdc.Position("pos"),
+		Operation: opencdc.OperationCreate,
+		Metadata:  opencdc.Metadata{"foo": "bar"},
+		Key:       opencdc.StructuredData{"id": 1},
+		Payload: opencdc.Change{
+			After: opencdc.StructuredData{
+				"name":  "foo",
+				"age":   "42",
+				"items": []any{"a", "b"},
+			},
+		},
+	}
+}
+
+func TestChain(t *testing.T) {
+	is := is.New(t)
+
+	in := testRecord()
+	tr := Chain(
+		Rename(opencdc.MustParsePath(".Key.id"), opencdc.MustParsePath(".Payload.After.id")),
+		Drop(opencdc.MustParsePath(".Payload.After.items")),
+		SetMetadata("source", "test"),
+		Cast(opencdc.MustParsePath(".Payload.After.age"), CastTypeInt),
+	)
+
+	got, err := tr(in)
+	is.NoErr(err)
+
+	want := opencdc.Record{
+		Position:  opencdc.Position("pos"),
+		Operation: opencdc.OperationCreate,
+		Metadata:  opencdc.Metadata{"foo": "bar", "source": "test"},
+		Key:       opencdc.StructuredData{},
+		Payload: opencdc.Change{
+			After: opencdc.StructuredData{
+				"id":   1,
+				"name": "foo",
+				"age":  int64(42),
+			},
+		},
+	}
+	is.Equal(cmp.Diff(want, got, exactRecord), "")
+	// input is not mutated
+	is.Equal(cmp.Diff(testRecord(), in, exactRecord), "")
+}
+
+func TestChain_Error(t *testing.T) {
+	is := is.New(t)
+
+	tr := Chain(
+		SetMetadata("source", "test"),
+		Cast(opencdc.MustParsePath(".Payload.After.name"), CastTypeInt),
+	)
+	_, err := tr(testRecord())
+	is.True(errors.Is(err, ErrInvalidCast))
+}
+
+func TestBuild(t *testing.T) {
+	is := is.New(t)
+
+	tr, err := Build(config.Config{
+		"0.type":   "rename",
+		"0.from":   ".Key.id",
+		"0.to":     ".Payload.After.id",
+		"1.type":   "copy",
+		"1.from":   ".Payload.After.name",
+		"1.to":     ".Metadata.name",
+		"2.type":   "drop",
+		"2.fields": ".Payload.After.items, .Payload.After.name",
+		"3.type":   "metadata.set",
+		"3.key":    "source",
+		"3.value":  "test",
+		"4.type":   "cast",
+		"4.field":  ".Payload.After.age",
+		"4.to":     "int",
+	})
+	is.NoErr(err)
+
+	got, err := tr(testRecord())
+	is.NoErr(err)
+
+	want := opencdc.Record{
+		Position:  opencdc.Position("pos"),
+		Operation: opencdc.OperationCreate,
+		Metadata:  opencdc.Metadata{"foo": "bar", "name": "foo", "source": "test"},
+		Key:       opencdc.StructuredData{},
+		Payload: opencdc.Change{
+			After: opencdc.StructuredData{
+				"id":  1,
+				"age": int64(42),
+			},
+		},
+	}
+	is.Equal(cmp.Diff(want, got, exactRecord), "")
+}
+
+func TestBuild_Errors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfg     config.Config
+		wantErr error
+	}{{
+		name:    "invalid key",
+		cfg:     config.Config{"foo": "bar"},
+		wantErr: ErrInvalidConfig,
+	}, {
+		name:    "missing type",
+		cfg:     config.Config{"0.from": ".Key"},
+		wantErr: ErrInvalidConfig,
+	}, {
+		name:    "unknown transform",
+		cfg:     config.Config{"0.type": "foo"},
+		wantErr: ErrUnknownTransform,
+	}, {
+		name:    "missing parameter",
+		cfg:     config.Config{"0.type": "rename", "0.from": ".Key"},
+		wantErr: config.ErrRequiredParameterMissing,
+	}, {
+		name:    "unrecognized parameter",
+		cfg:     config.Config{"0.type": "metadata.set", "0.key": "foo", "0.foo": "bar"},
+		wantErr: config.ErrUnrecognizedParameter,
+	}, {
+		name:    "invalid path",
+		cfg:     config.Config{"0.type": "rename", "0.from": ".Foo", "0.to": ".Key"},
+		wantErr: opencdc.ErrInvalidPath,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			is := is.New(t)
+			_, err := Build(tc.cfg)
+			is.True(errors.Is(err, tc.wantErr))
+		})
+	}
+}
+
+func TestSpecs(t *testing.T) {
+	is := is.New(t)
+	specs := Specs()
+	for name, spec := range specs {
+		is.Equal(name, spec.Name)
+		is.True(spec.Summary != "")
+		is.True(spec.New != nil)
+		is.True(len(spec.Parameters) > 0)
+	}
+	_, ok := specs["rename"]
+	is.True(ok)
+}